@@ -19,6 +19,7 @@ package v1alpha1
 import (
 	"sort"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -38,11 +39,438 @@ type ClusterResources struct {
 	Pods    string `json:"pods,omitempty"`
 	Storage string `json:"storage,omitempty"`
 	GPU     string `json:"gpu,omitempty"`
+	// Utilization compares the capacity of the cpu and memory to the requests and limits of the pods and to the
+	// usage reported by the metrics API.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Nullable
+	Utilization []ResourceUtilization `json:"utilization,omitempty" bson:"utilization,omitempty"`
+}
+
+// ResourceUtilization describes the capacity and utilization of a resource of the nodes.
+type ResourceUtilization struct {
+	// Resource is cpu or memory.
+	Resource    string `json:"resource" bson:"resource"`
+	Capacity    string `json:"capacity" bson:"capacity"`
+	Allocatable string `json:"allocatable" bson:"allocatable"`
+	// Requested and Limits are the totals of the requests and limits of the pods scheduled on the nodes.
+	Requested string `json:"requested" bson:"requested"`
+	Limits    string `json:"limits" bson:"limits"`
+	// Used is the current usage reported by metrics.k8s.io. It is empty when the metrics API is not available.
+	Used string `json:"used,omitempty" bson:"used,omitempty"`
 }
 
 type StorageProvisioner struct {
 	Name        string `json:"name"`
 	Provisioner string `json:"provisioner"`
+	// Default tells whether the StorageClass is the default one of the cluster.
+	Default              bool   `json:"default,omitempty" bson:"default,omitempty"`
+	ReclaimPolicy        string `json:"reclaimPolicy,omitempty" bson:"reclaimPolicy,omitempty"`
+	VolumeBindingMode    string `json:"volumeBindingMode,omitempty" bson:"volumeBindingMode,omitempty"`
+	AllowVolumeExpansion bool   `json:"allowVolumeExpansion,omitempty" bson:"allowVolumeExpansion,omitempty"`
+	// Parameters are the StorageClass parameters, with the values of credentials redacted.
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty" bson:"parameters,omitempty"`
+	// CSIDriver holds the capabilities of the CSI driver of the provisioner, when it is a CSI driver.
+	// +optional
+	CSIDriver *CSIDriver `json:"csiDriver,omitempty" bson:"csiDriver,omitempty"`
+	// ProvisionedCapacity is the total capacity, in MiB, of the PersistentVolumes of the StorageClass.
+	ProvisionedCapacity string `json:"provisionedCapacity,omitempty" bson:"provisionedCapacity,omitempty"`
+}
+
+// CSIDriver describes the capabilities of a CSI driver, taken from its CSIDriver object.
+type CSIDriver struct {
+	AttachRequired    bool   `json:"attachRequired,omitempty" bson:"attachRequired,omitempty"`
+	PodInfoOnMount    bool   `json:"podInfoOnMount,omitempty" bson:"podInfoOnMount,omitempty"`
+	StorageCapacity   bool   `json:"storageCapacity,omitempty" bson:"storageCapacity,omitempty"`
+	RequiresRepublish bool   `json:"requiresRepublish,omitempty" bson:"requiresRepublish,omitempty"`
+	FSGroupPolicy     string `json:"fsGroupPolicy,omitempty" bson:"fsGroupPolicy,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Nullable
+	VolumeLifecycleModes []string `json:"volumeLifecycleModes,omitempty" bson:"volumeLifecycleModes,omitempty"`
+}
+
+// Segment describes a network segment used by the cluster, enriched with its NetBox IPAM metadata.
+type Segment struct {
+	Prefix string `json:"prefix" bson:"prefix"`
+	// Family is the IP family of the prefix.
+	// +optional
+	Family      corev1.IPFamily `json:"family,omitempty" bson:"family,omitempty"`
+	VLANID      int             `json:"vlanID,omitempty" bson:"vlanID,omitempty"`
+	VLANName    string          `json:"vlanName,omitempty" bson:"vlanName,omitempty"`
+	VRF         string          `json:"vrf,omitempty" bson:"vrf,omitempty"`
+	Site        string          `json:"site,omitempty" bson:"site,omitempty"`
+	Role        string          `json:"role,omitempty" bson:"role,omitempty"`
+	Tenant      string          `json:"tenant,omitempty" bson:"tenant,omitempty"`
+	Status      string          `json:"status,omitempty" bson:"status,omitempty"`
+	Description string          `json:"description,omitempty" bson:"description,omitempty"`
+	// Interfaces and Nodes list the node interfaces holding an address in the segment.
+	// They are only populated for segments discovered from NodeNetworkState.
+	Interfaces []string `json:"interfaces,omitempty" bson:"interfaces,omitempty"`
+	Nodes      []string `json:"nodes,omitempty" bson:"nodes,omitempty"`
+}
+
+// NetworkInterface describes a network interface of a node as reported by NodeNetworkState.
+type NetworkInterface struct {
+	Name       string `json:"name" bson:"name"`
+	Type       string `json:"type,omitempty" bson:"type,omitempty"`
+	State      string `json:"state,omitempty" bson:"state,omitempty"`
+	MTU        int    `json:"mtu,omitempty" bson:"mtu,omitempty"`
+	MACAddress string `json:"macAddress,omitempty" bson:"macAddress,omitempty"`
+	// BondMode and BondPorts are only set for bond interfaces.
+	BondMode  string   `json:"bondMode,omitempty" bson:"bondMode,omitempty"`
+	BondPorts []string `json:"bondPorts,omitempty" bson:"bondPorts,omitempty"`
+	// VLANBaseInterface and VLANID are only set for VLAN interfaces.
+	VLANBaseInterface string `json:"vlanBaseInterface,omitempty" bson:"vlanBaseInterface,omitempty"`
+	VLANID            int    `json:"vlanID,omitempty" bson:"vlanID,omitempty"`
+	// Addresses lists the IPv4 and IPv6 addresses of the interface in CIDR notation.
+	Addresses []string `json:"addresses,omitempty" bson:"addresses,omitempty"`
+}
+
+// NetworkRoute describes a route of the main routing table of a node.
+type NetworkRoute struct {
+	Destination      string `json:"destination" bson:"destination"`
+	NextHopAddress   string `json:"nextHopAddress,omitempty" bson:"nextHopAddress,omitempty"`
+	NextHopInterface string `json:"nextHopInterface,omitempty" bson:"nextHopInterface,omitempty"`
+	Metric           int    `json:"metric,omitempty" bson:"metric,omitempty"`
+}
+
+// NodeNetworkInterfaces holds the network interfaces and routes of a node.
+type NodeNetworkInterfaces struct {
+	Node       string             `json:"node" bson:"node"`
+	Interfaces []NetworkInterface `json:"interfaces,omitempty" bson:"interfaces,omitempty"`
+	Routes     []NetworkRoute     `json:"routes,omitempty" bson:"routes,omitempty"`
+	// DefaultGateways lists the next hop addresses of the IPv4 and IPv6 default routes.
+	DefaultGateways []string `json:"defaultGateways,omitempty" bson:"defaultGateways,omitempty"`
+}
+
+// ClusterNetworkEntry is a pod network of the cluster and the size of the subnet allocated to each node.
+type ClusterNetworkEntry struct {
+	CIDR       string `json:"cidr" bson:"cidr"`
+	HostPrefix int    `json:"hostPrefix,omitempty" bson:"hostPrefix,omitempty"`
+}
+
+// ClusterNetwork describes the network plugin and the pod, service and machine networks of the cluster.
+type ClusterNetwork struct {
+	// NetworkType is the network plugin of the cluster, e.g. OVNKubernetes or Calico.
+	NetworkType string `json:"networkType,omitempty" bson:"networkType,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Nullable
+	ClusterNetworks []ClusterNetworkEntry `json:"clusterNetworks,omitempty" bson:"clusterNetworks,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Nullable
+	ServiceNetworks []string `json:"serviceNetworks,omitempty" bson:"serviceNetworks,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Nullable
+	MachineNetworks []string `json:"machineNetworks,omitempty" bson:"machineNetworks,omitempty"`
+	// MTU is the MTU of the pod network.
+	MTU int `json:"mtu,omitempty" bson:"mtu,omitempty"`
+	// Source describes where the configuration was read from.
+	Source string `json:"source,omitempty" bson:"source,omitempty"`
+}
+
+// IngressController describes an OpenShift IngressController and the addresses it is published on.
+type IngressController struct {
+	Name   string `json:"name" bson:"name"`
+	Domain string `json:"domain,omitempty" bson:"domain,omitempty"`
+	// EndpointPublishingStrategy is the type of the endpoint publishing strategy, e.g. LoadBalancerService or HostNetwork.
+	EndpointPublishingStrategy string `json:"endpointPublishingStrategy,omitempty" bson:"endpointPublishingStrategy,omitempty"`
+	Replicas                   int32  `json:"replicas,omitempty" bson:"replicas,omitempty"`
+	AvailableReplicas          int32  `json:"availableReplicas,omitempty" bson:"availableReplicas,omitempty"`
+	// DefaultCertificate is the name of the Secret in the openshift-ingress namespace holding the default serving
+	// certificate of the router. It is empty when the router uses the certificate generated by the ingress operator.
+	DefaultCertificate string `json:"defaultCertificate,omitempty" bson:"defaultCertificate,omitempty"`
+	// LoadBalancerAddresses lists the IPs and hostnames of the load balancer service of the router.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Nullable
+	LoadBalancerAddresses []string `json:"loadBalancerAddresses,omitempty" bson:"loadBalancerAddresses,omitempty"`
+}
+
+// Platform describes the infrastructure platform and topology of the cluster.
+type Platform struct {
+	// Type is the infrastructure platform, e.g. BareMetal, VSphere, AWS or None.
+	Type string `json:"type,omitempty" bson:"type,omitempty"`
+	// ControlPlaneTopology is HighlyAvailable, SingleReplica or External for hosted control planes.
+	ControlPlaneTopology string `json:"controlPlaneTopology,omitempty" bson:"controlPlaneTopology,omitempty"`
+	// InfrastructureTopology is the topology of the infrastructure nodes, HighlyAvailable or SingleReplica.
+	InfrastructureTopology string `json:"infrastructureTopology,omitempty" bson:"infrastructureTopology,omitempty"`
+	Region                 string `json:"region,omitempty" bson:"region,omitempty"`
+	// Zones lists the topology zones of the nodes.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Nullable
+	Zones []string `json:"zones,omitempty" bson:"zones,omitempty"`
+	// Providers lists the providers found in the providerID of the nodes, e.g. aws or vsphere.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Nullable
+	Providers []string `json:"providers,omitempty" bson:"providers,omitempty"`
+}
+
+// IdentityProvider describes an identity provider configured for the cluster OAuth server.
+type IdentityProvider struct {
+	Name string `json:"name" bson:"name"`
+	// Type is the identity provider type, e.g. OpenID, LDAP or HTPasswd.
+	Type          string `json:"type,omitempty" bson:"type,omitempty"`
+	MappingMethod string `json:"mappingMethod,omitempty" bson:"mappingMethod,omitempty"`
+	// URL is the issuer of OpenID providers or the remote URL, hostname or domain of the other providers.
+	URL string `json:"url,omitempty" bson:"url,omitempty"`
+}
+
+// OperatorCondition is a condition reported by an OpenShift operator, e.g. Available, Progressing or Degraded.
+type OperatorCondition struct {
+	Type               string      `json:"type" bson:"type"`
+	Status             string      `json:"status" bson:"status"`
+	Reason             string      `json:"reason,omitempty" bson:"reason,omitempty"`
+	Message            string      `json:"message,omitempty" bson:"message,omitempty"`
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty" bson:"lastTransitionTime,omitempty"`
+}
+
+// ClusterVersionUpdate is an entry of the update history of the cluster.
+type ClusterVersionUpdate struct {
+	Version string `json:"version" bson:"version"`
+	// State is Completed when the update was fully applied, or Partial.
+	State          string       `json:"state" bson:"state"`
+	StartedTime    metav1.Time  `json:"startedTime" bson:"startedTime"`
+	CompletionTime *metav1.Time `json:"completionTime,omitempty" bson:"completionTime,omitempty"`
+	Image          string       `json:"image,omitempty" bson:"image,omitempty"`
+}
+
+// ClusterVersionInfo describes the OpenShift version, update channel and update history of the cluster.
+type ClusterVersionInfo struct {
+	Version string `json:"version,omitempty" bson:"version,omitempty"`
+	// Image is the release image of the desired version.
+	Image   string `json:"image,omitempty" bson:"image,omitempty"`
+	Channel string `json:"channel,omitempty" bson:"channel,omitempty"`
+	// AvailableUpdates lists the versions the cluster can be updated to.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Nullable
+	AvailableUpdates []string `json:"availableUpdates,omitempty" bson:"availableUpdates,omitempty"`
+	// History lists the updates of the cluster, the most recent first.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Nullable
+	History []ClusterVersionUpdate `json:"history,omitempty" bson:"history,omitempty"`
+	// Conditions holds the Available, Progressing, Failing and Upgradeable conditions of the ClusterVersion.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Nullable
+	Conditions []OperatorCondition `json:"conditions,omitempty" bson:"conditions,omitempty"`
+}
+
+// OperandVersion is a version reported by a ClusterOperator, e.g. of the operator itself or of its operands.
+type OperandVersion struct {
+	Name    string `json:"name" bson:"name"`
+	Version string `json:"version" bson:"version"`
+}
+
+// ClusterOperator summarizes the health of an OpenShift ClusterOperator.
+type ClusterOperator struct {
+	Name string `json:"name" bson:"name"`
+	// Available, Progressing and Degraded hold the status of the matching conditions: True, False or Unknown.
+	Available   string `json:"available,omitempty" bson:"available,omitempty"`
+	Progressing string `json:"progressing,omitempty" bson:"progressing,omitempty"`
+	Degraded    string `json:"degraded,omitempty" bson:"degraded,omitempty"`
+	// Message explains why the operator is degraded or unavailable.
+	Message string `json:"message,omitempty" bson:"message,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Nullable
+	Versions []OperandVersion `json:"versions,omitempty" bson:"versions,omitempty"`
+}
+
+// OLMOperator describes an operator installed by OLM through a Subscription and its ClusterServiceVersion.
+type OLMOperator struct {
+	Name                string `json:"name" bson:"name"`
+	Namespace           string `json:"namespace" bson:"namespace"`
+	Package             string `json:"package,omitempty" bson:"package,omitempty"`
+	Channel             string `json:"channel,omitempty" bson:"channel,omitempty"`
+	CatalogSource       string `json:"catalogSource,omitempty" bson:"catalogSource,omitempty"`
+	InstallPlanApproval string `json:"installPlanApproval,omitempty" bson:"installPlanApproval,omitempty"`
+	InstalledCSV        string `json:"installedCSV,omitempty" bson:"installedCSV,omitempty"`
+	Version             string `json:"version,omitempty" bson:"version,omitempty"`
+	// Phase is the phase of the installed ClusterServiceVersion, e.g. Succeeded or Failed.
+	Phase string `json:"phase,omitempty" bson:"phase,omitempty"`
+}
+
+// CustomResourceDefinition describes a CRD and the versions it serves.
+type CustomResourceDefinition struct {
+	Name  string `json:"name" bson:"name"`
+	Group string `json:"group" bson:"group"`
+	Kind  string `json:"kind" bson:"kind"`
+	Scope string `json:"scope,omitempty" bson:"scope,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Nullable
+	ServedVersions []string `json:"servedVersions,omitempty" bson:"servedVersions,omitempty"`
+	StorageVersion string   `json:"storageVersion,omitempty" bson:"storageVersion,omitempty"`
+}
+
+// APIService describes an aggregated API served by an in-cluster service.
+type APIService struct {
+	Name string `json:"name" bson:"name"`
+	// Service is the namespace/name of the service serving the API.
+	Service string `json:"service" bson:"service"`
+	// Available is the status of the Available condition: True, False or Unknown.
+	Available string `json:"available,omitempty" bson:"available,omitempty"`
+	Message   string `json:"message,omitempty" bson:"message,omitempty"`
+}
+
+// APIChange records a change to the CRDs or aggregated APIs of the cluster.
+type APIChange struct {
+	Time metav1.Time `json:"time" bson:"time"`
+	// Resource is CustomResourceDefinition or APIService.
+	Resource string `json:"resource" bson:"resource"`
+	Name     string `json:"name" bson:"name"`
+	Change   string `json:"change" bson:"change"`
+}
+
+// Admission webhook types.
+const (
+	WebhookTypeMutating   = "Mutating"
+	WebhookTypeValidating = "Validating"
+)
+
+// WebhookRule describes the operations and resources an admission webhook intercepts.
+type WebhookRule struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Nullable
+	Operations []string `json:"operations,omitempty" bson:"operations,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Nullable
+	APIGroups []string `json:"apiGroups,omitempty" bson:"apiGroups,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Nullable
+	APIVersions []string `json:"apiVersions,omitempty" bson:"apiVersions,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Nullable
+	Resources []string `json:"resources,omitempty" bson:"resources,omitempty"`
+	Scope     string   `json:"scope,omitempty" bson:"scope,omitempty"`
+}
+
+// AdmissionWebhook describes a webhook of a mutating or validating webhook configuration.
+type AdmissionWebhook struct {
+	Name          string `json:"name" bson:"name"`
+	Configuration string `json:"configuration" bson:"configuration"`
+	// +kubebuilder:validation:Enum=Mutating;Validating
+	Type           string `json:"type" bson:"type"`
+	FailurePolicy  string `json:"failurePolicy,omitempty" bson:"failurePolicy,omitempty"`
+	SideEffects    string `json:"sideEffects,omitempty" bson:"sideEffects,omitempty"`
+	TimeoutSeconds int32  `json:"timeoutSeconds,omitempty" bson:"timeoutSeconds,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Nullable
+	Rules []WebhookRule `json:"rules,omitempty" bson:"rules,omitempty"`
+	// NamespaceSelector and ObjectSelector are the label selectors of the webhook in their string form.
+	NamespaceSelector string `json:"namespaceSelector,omitempty" bson:"namespaceSelector,omitempty"`
+	ObjectSelector    string `json:"objectSelector,omitempty" bson:"objectSelector,omitempty"`
+	// Service is the namespace/name of the service backing the webhook. It is empty when the webhook is called by URL.
+	Service string `json:"service,omitempty" bson:"service,omitempty"`
+	URL     string `json:"url,omitempty" bson:"url,omitempty"`
+	// ServiceReady tells whether the service backing the webhook has ready endpoints.
+	// +optional
+	ServiceReady *bool `json:"serviceReady,omitempty" bson:"serviceReady,omitempty"`
+}
+
+// StorageClassUsage aggregates the PersistentVolumes and PersistentVolumeClaims of a StorageClass.
+type StorageClassUsage struct {
+	// StorageClass is empty for the volumes and claims without a StorageClass.
+	StorageClass string `json:"storageClass" bson:"storageClass"`
+	// Volumes counts the PersistentVolumes by phase, e.g. Bound, Released or Failed.
+	// +optional
+	Volumes map[string]int `json:"volumes,omitempty" bson:"volumes,omitempty"`
+	// Claims counts the PersistentVolumeClaims by phase, e.g. Bound or Pending.
+	// +optional
+	Claims map[string]int `json:"claims,omitempty" bson:"claims,omitempty"`
+	// Capacity is the total capacity of the PersistentVolumes and Requested the total storage requested by the
+	// PersistentVolumeClaims.
+	Capacity  string `json:"capacity,omitempty" bson:"capacity,omitempty"`
+	Requested string `json:"requested,omitempty" bson:"requested,omitempty"`
+}
+
+// StorageUsage describes the persistent storage usage of the cluster.
+type StorageUsage struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Nullable
+	StorageClasses []StorageClassUsage `json:"storageClasses,omitempty" bson:"storageClasses,omitempty"`
+	// OrphanedVolumes counts the Released PersistentVolumes, whose claim was deleted but which still hold their
+	// storage, and OrphanedCapacity is their total capacity.
+	OrphanedVolumes  int    `json:"orphanedVolumes,omitempty" bson:"orphanedVolumes,omitempty"`
+	OrphanedCapacity string `json:"orphanedCapacity,omitempty" bson:"orphanedCapacity,omitempty"`
+	Capacity         string `json:"capacity,omitempty" bson:"capacity,omitempty"`
+	Requested        string `json:"requested,omitempty" bson:"requested,omitempty"`
+}
+
+// WorkloadCounts counts the namespaces and workloads of a set of namespaces.
+type WorkloadCounts struct {
+	Namespaces   int `json:"namespaces" bson:"namespaces"`
+	Deployments  int `json:"deployments" bson:"deployments"`
+	StatefulSets int `json:"statefulSets" bson:"statefulSets"`
+	// Pods counts the pods by phase, e.g. Running or Pending.
+	// +optional
+	Pods map[string]int `json:"pods,omitempty" bson:"pods,omitempty"`
+}
+
+// OwnerWorkloads counts the namespaces and workloads of an owner. Namespaces without the owner label are counted
+// under an empty owner.
+type OwnerWorkloads struct {
+	Owner          string `json:"owner" bson:"owner"`
+	WorkloadCounts `json:",inline" bson:",inline"`
+}
+
+// Workloads summarizes the namespaces and workloads of the cluster, leaving out the system namespaces.
+type Workloads struct {
+	WorkloadCounts `json:",inline" bson:",inline"`
+	// SystemNamespaces counts the namespaces left out as system namespaces.
+	SystemNamespaces int `json:"systemNamespaces" bson:"systemNamespaces"`
+	// Owners summarizes the workloads per value of spec.workloads.ownerLabel.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Nullable
+	Owners []OwnerWorkloads `json:"owners,omitempty" bson:"owners,omitempty"`
+}
+
+// ResourceOvercommit compares the ResourceQuota totals of a resource to its capacity in the cluster.
+type ResourceOvercommit struct {
+	// Resource is cpu, memory or gpu.
+	Resource string `json:"resource" bson:"resource"`
+	// Capacity is the capacity of the nodes, as in clusterResources.
+	Capacity string `json:"capacity" bson:"capacity"`
+	// Requests and Limits are the totals of the requests and limits hard limits of the ResourceQuotas.
+	Requests string `json:"requests,omitempty" bson:"requests,omitempty"`
+	Limits   string `json:"limits,omitempty" bson:"limits,omitempty"`
+	// RequestsRatio and LimitsRatio divide the quota totals by the capacity, e.g. "1.50" for 150% of the capacity.
+	// They are empty when the capacity is zero.
+	RequestsRatio string `json:"requestsRatio,omitempty" bson:"requestsRatio,omitempty"`
+	LimitsRatio   string `json:"limitsRatio,omitempty" bson:"limitsRatio,omitempty"`
+}
+
+// QuotaOvercommit describes how much the ResourceQuotas of the cluster overcommit its capacity.
+type QuotaOvercommit struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Nullable
+	Resources []ResourceOvercommit `json:"resources,omitempty" bson:"resources,omitempty"`
+	// ResourceQuotas counts the ResourceQuotas and LimitRangeNamespaces the namespaces with a LimitRange.
+	ResourceQuotas       int `json:"resourceQuotas" bson:"resourceQuotas"`
+	LimitRangeNamespaces int `json:"limitRangeNamespaces" bson:"limitRangeNamespaces"`
+}
+
+// MachineConfigPool describes an OpenShift MachineConfigPool and the progress of its updates.
+type MachineConfigPool struct {
+	Name                    string `json:"name" bson:"name"`
+	MachineCount            int32  `json:"machineCount" bson:"machineCount"`
+	ReadyMachineCount       int32  `json:"readyMachineCount" bson:"readyMachineCount"`
+	UpdatedMachineCount     int32  `json:"updatedMachineCount" bson:"updatedMachineCount"`
+	UnavailableMachineCount int32  `json:"unavailableMachineCount" bson:"unavailableMachineCount"`
+	DegradedMachineCount    int32  `json:"degradedMachineCount" bson:"degradedMachineCount"`
+	// RenderedConfig is the name of the rendered MachineConfig currently applied to the pool.
+	RenderedConfig string `json:"renderedConfig,omitempty" bson:"renderedConfig,omitempty"`
+	Paused         bool   `json:"paused,omitempty" bson:"paused,omitempty"`
+	// Updating and Degraded reflect the conditions of the same type.
+	Updating bool `json:"updating,omitempty" bson:"updating,omitempty"`
+	Degraded bool `json:"degraded,omitempty" bson:"degraded,omitempty"`
+}
+
+// MachineSet describes an OpenShift MachineSet. The instance type, region and zone are taken from its Machines.
+type MachineSet struct {
+	Name              string `json:"name" bson:"name"`
+	Replicas          int32  `json:"replicas" bson:"replicas"`
+	ReadyReplicas     int32  `json:"readyReplicas" bson:"readyReplicas"`
+	AvailableReplicas int32  `json:"availableReplicas" bson:"availableReplicas"`
+	InstanceType      string `json:"instanceType,omitempty" bson:"instanceType,omitempty"`
+	Region            string `json:"region,omitempty" bson:"region,omitempty"`
+	Zone              string `json:"zone,omitempty" bson:"zone,omitempty"`
 }
 
 type ClusterDnsConfig struct {
@@ -54,25 +482,209 @@ type ClusterDnsConfig struct {
 	Servers []string `json:"servers,omitempty"`
 }
 
+// SegmentDrift lists the differences between the segments used by the cluster nodes
+// and the prefixes assigned to the cluster in NetBox through the Cluster custom field.
+type SegmentDrift struct {
+	// Unregistered lists segments used by the nodes that are not assigned to the cluster in NetBox.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Nullable
+	Unregistered []string `json:"unregistered,omitempty" bson:"unregistered,omitempty"`
+	// Unused lists prefixes assigned to the cluster in NetBox that are not used by any node.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Nullable
+	Unused []string `json:"unused,omitempty" bson:"unused,omitempty"`
+}
+
+// NetBoxWriteBackSpec configures registering the discovered cluster facts in NetBox.
+type NetBoxWriteBackSpec struct {
+	// Enabled registers the cluster, its nodes with their internal IPs and tags the cluster prefixes in NetBox.
+	// +optional
+	Enabled bool `json:"enabled,omitempty" bson:"enabled,omitempty"`
+	// DryRun reports the changes that would be made in NetBox without applying them.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty" bson:"dryRun,omitempty"`
+	// ClusterType is the slug of the NetBox cluster type used when the cluster is created.
+	// +kubebuilder:default=openshift
+	// +optional
+	ClusterType string `json:"clusterType,omitempty" bson:"clusterType,omitempty"`
+}
+
+// NetBoxSpec configures the NetBox integration.
+type NetBoxSpec struct {
+	// SecretRef references a Secret holding the NetBox "url" and "token" and optionally a "ca.crt" bundle
+	// used to verify the NetBox certificate. The Secret must be in the operator namespace, the only namespace
+	// the operator reads Secrets from, which is also the default.
+	// Changes to the Secret are picked up without restarting the operator.
+	// When unset, the NETBOX_* environment variables of the operator are used.
+	// +optional
+	SecretRef *corev1.SecretReference `json:"secretRef,omitempty" bson:"secretRef,omitempty"`
+	// DriftDetection compares the segments discovered on the nodes with the prefixes
+	// assigned to the cluster in NetBox and reports the differences in the status.
+	// +optional
+	DriftDetection bool `json:"driftDetection,omitempty" bson:"driftDetection,omitempty"`
+	// +optional
+	WriteBack *NetBoxWriteBackSpec `json:"writeBack,omitempty" bson:"writeBack,omitempty"`
+}
+
+// NetBoxSync reports the result of the last write-back to NetBox.
+type NetBoxSync struct {
+	DryRun bool `json:"dryRun,omitempty" bson:"dryRun,omitempty"`
+	// Changes lists the changes applied, or planned in dry-run mode, by the last write-back.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Nullable
+	Changes []string `json:"changes,omitempty" bson:"changes,omitempty"`
+}
+
+// ManagementClusterSpec locates the HostedCluster of a hosted cluster on its management cluster.
+type ManagementClusterSpec struct {
+	// KubeconfigSecretRef references a Secret holding, under the "kubeconfig" key, a kubeconfig of the management
+	// cluster allowed to get the HostedCluster. The Secret must be in the operator namespace, the only namespace
+	// the operator reads Secrets from, which is also the default.
+	KubeconfigSecretRef corev1.SecretReference `json:"kubeconfigSecretRef" bson:"kubeconfigSecretRef"`
+	// HostedClusterName and HostedClusterNamespace identify the HostedCluster on the management cluster.
+	HostedClusterName      string `json:"hostedClusterName" bson:"hostedClusterName"`
+	HostedClusterNamespace string `json:"hostedClusterNamespace" bson:"hostedClusterNamespace"`
+}
+
+// SegmentsSpec configures the discovery of segments from NodeNetworkState.
+type SegmentsSpec struct {
+	// IncludeLinkLocal also reports the link-local segments, 169.254.0.0/16 and fe80::/10, of the nodes.
+	// +optional
+	IncludeLinkLocal bool `json:"includeLinkLocal,omitempty" bson:"includeLinkLocal,omitempty"`
+}
+
+// WorkloadsSpec configures the workload summary.
+type WorkloadsSpec struct {
+	// SystemNamespacePatterns are the glob patterns of the system namespaces, left out of the summary.
+	// Defaults to openshift-* and kube-*.
+	// +optional
+	SystemNamespacePatterns []string `json:"systemNamespacePatterns,omitempty" bson:"systemNamespacePatterns,omitempty"`
+	// OwnerLabel is the namespace label holding the team owning the namespace. When set, the workloads are also
+	// summarized per owner.
+	// +optional
+	OwnerLabel string `json:"ownerLabel,omitempty" bson:"ownerLabel,omitempty"`
+}
+
 // ClusterInfoSpec defines the desired state of ClusterInfo.
 type ClusterInfoSpec struct {
-	HostedCluster bool `json:"hostedCluster,omitempty" bson:"hostedCluster,omitempty"`
+	// HostedCluster overrides the detected mode of the cluster, reported in status.detectedMode.
+	// Set it to true for clusters with a hosted control plane and to false for standalone clusters.
+	// +optional
+	HostedCluster *bool `json:"hostedCluster,omitempty" bson:"hostedCluster,omitempty"`
+	// ClusterName overrides the cluster name, otherwise derived from the API server URL on OpenShift and from the
+	// kubeadm configuration or the API server host on plain Kubernetes.
+	// +optional
+	ClusterName string `json:"clusterName,omitempty" bson:"clusterName,omitempty"`
+	// +optional
+	Segments *SegmentsSpec `json:"segments,omitempty" bson:"segments,omitempty"`
+	// ManagementCluster is used to read the configuration of hosted clusters from their HostedCluster.
+	// +optional
+	ManagementCluster *ManagementClusterSpec `json:"managementCluster,omitempty" bson:"managementCluster,omitempty"`
+	// +optional
+	NetBox *NetBoxSpec `json:"netbox,omitempty" bson:"netbox,omitempty"`
+	// +optional
+	Workloads *WorkloadsSpec `json:"workloads,omitempty" bson:"workloads,omitempty"`
 }
 
+const (
+	// ClusterModeHosted is the mode of clusters whose control plane is hosted outside the cluster, e.g. by HyperShift.
+	ClusterModeHosted = "Hosted"
+	// ClusterModeStandalone is the mode of clusters running their own control plane.
+	ClusterModeStandalone = "Standalone"
+)
+
+const (
+	// DistributionOpenShift is the distribution of clusters serving the OpenShift APIs.
+	DistributionOpenShift = "OpenShift"
+	// DistributionKubernetes is the distribution of plain Kubernetes clusters, e.g. EKS, kind or k3s.
+	DistributionKubernetes = "Kubernetes"
+)
+
+const (
+	// ConditionSegmentsInSync reports whether the segments used by the nodes match the prefixes registered in NetBox.
+	ConditionSegmentsInSync = "SegmentsInSync"
+	// ConditionNetBoxSynced reports whether the cluster facts were written back to NetBox.
+	ConditionNetBoxSynced = "NetBoxSynced"
+	// ConditionNetBoxConfigured reports whether the NetBox URL and token could be resolved.
+	ConditionNetBoxConfigured = "NetBoxConfigured"
+	// ConditionIdentityProvidersCollected reports whether the identity providers of the cluster could be collected.
+	ConditionIdentityProvidersCollected = "IdentityProvidersCollected"
+)
+
 type ClusterInfoStatus struct {
-	Name                string               `json:"name,omitempty" bson:"name,omitempty"`
-	ClusterID           string               `json:"clusterID,omitempty" bson:"clusterID,omitempty"`
-	KubernetesVersion   string               `json:"kubernetesVersion,omitempty" bson:"kubernetesVersion,omitempty"`
-	ClusterDnsConfig    ClusterDnsConfig     `json:"clusterDnsConfig,omitempty" bson:"clusterDnsConfig,omitempty"`
-	ClusterResources    ClusterResources     `json:"clusterResources,omitempty" bson:"clusterResources,omitempty"`
-	NodeInfo            []NodeInfo           `json:"nodeInfo,omitempty" bson:"nodeInfo,omitempty"`
-	RouterLBAddresses   []string             `json:"routerLBAddress,omitempty" bson:"routerLBAddress,omitempty"`
-	ApiServerAddresses  []string             `json:"apiServerAddresses,omitempty" bson:"apiServerAddresses,omitempty"`
-	IdentityProviders   []string             `json:"identityProviders,omitempty" bson:"identityProviders,omitempty"`
+	// DetectedMode is the mode detected for the cluster, Hosted or Standalone. It is used unless overridden by
+	// spec.hostedCluster.
+	// +kubebuilder:validation:Enum=Hosted;Standalone
+	// +optional
+	DetectedMode string `json:"detectedMode,omitempty" bson:"detectedMode,omitempty"`
+	// Distribution is the detected distribution of the cluster, OpenShift or Kubernetes.
+	// +kubebuilder:validation:Enum=OpenShift;Kubernetes
+	// +optional
+	Distribution       string           `json:"distribution,omitempty" bson:"distribution,omitempty"`
+	Name               string           `json:"name,omitempty" bson:"name,omitempty"`
+	ClusterID          string           `json:"clusterID,omitempty" bson:"clusterID,omitempty"`
+	KubernetesVersion  string           `json:"kubernetesVersion,omitempty" bson:"kubernetesVersion,omitempty"`
+	ClusterDnsConfig   ClusterDnsConfig `json:"clusterDnsConfig,omitempty" bson:"clusterDnsConfig,omitempty"`
+	ClusterResources   ClusterResources `json:"clusterResources,omitempty" bson:"clusterResources,omitempty"`
+	NodeInfo           []NodeInfo       `json:"nodeInfo,omitempty" bson:"nodeInfo,omitempty"`
+	RouterLBAddresses  []string         `json:"routerLBAddress,omitempty" bson:"routerLBAddress,omitempty"`
+	ApiServerAddresses []string         `json:"apiServerAddresses,omitempty" bson:"apiServerAddresses,omitempty"`
+	// APIServerURL, APIServerInternalURL and InfrastructureName are taken from the cluster Infrastructure.
+	APIServerURL         string `json:"apiServerURL,omitempty" bson:"apiServerURL,omitempty"`
+	APIServerInternalURL string `json:"apiServerInternalURL,omitempty" bson:"apiServerInternalURL,omitempty"`
+	InfrastructureName   string `json:"infrastructureName,omitempty" bson:"infrastructureName,omitempty"`
+	// +optional
+	ClusterVersion *ClusterVersionInfo `json:"clusterVersion,omitempty" bson:"clusterVersion,omitempty"`
+	// +optional
+	Platform *Platform `json:"platform,omitempty" bson:"platform,omitempty"`
+	// +optional
+	ClusterOperators []ClusterOperator `json:"clusterOperators,omitempty" bson:"clusterOperators,omitempty"`
+	// PlatformHealthy is true when all the ClusterOperators are available and none is degraded.
+	// It is unset on plain Kubernetes.
+	// +optional
+	PlatformHealthy *bool `json:"platformHealthy,omitempty" bson:"platformHealthy,omitempty"`
+	// +optional
+	IngressControllers  []IngressController  `json:"ingressControllers,omitempty" bson:"ingressControllers,omitempty"`
+	IdentityProviders   []IdentityProvider   `json:"identityProviders,omitempty" bson:"identityProviders,omitempty"`
 	StorageProvisioners []StorageProvisioner `json:"storageProvisioners,omitempty" bson:"storageProvisioners,omitempty"`
 	MutatingWebhooks    []string             `json:"mutatingWebhooks,omitempty" bson:"mutatingWebhooks,omitempty"`
 	ValidatingWebhooks  []string             `json:"validatingWebhooks,omitempty" bson:"validatingWebhooks,omitempty"`
-	Segments            []string             `json:"segments,omitempty" bson:"segments,omitempty"`
+	Segments            []Segment            `json:"segments,omitempty" bson:"segments,omitempty"`
+	SegmentDrift        *SegmentDrift        `json:"segmentDrift,omitempty" bson:"segmentDrift,omitempty"`
+	NetBoxSync          *NetBoxSync          `json:"netboxSync,omitempty" bson:"netboxSync,omitempty"`
+	// AdmissionWebhooks are the webhooks of the MutatingWebhooks and ValidatingWebhooks configurations.
+	// +optional
+	AdmissionWebhooks []AdmissionWebhook `json:"admissionWebhooks,omitempty" bson:"admissionWebhooks,omitempty"`
+	// +optional
+	Workloads *Workloads `json:"workloads,omitempty" bson:"workloads,omitempty"`
+	// +optional
+	QuotaOvercommit *QuotaOvercommit `json:"quotaOvercommit,omitempty" bson:"quotaOvercommit,omitempty"`
+	// +optional
+	MachineConfigPools []MachineConfigPool `json:"machineConfigPools,omitempty" bson:"machineConfigPools,omitempty"`
+	// +optional
+	MachineSets []MachineSet `json:"machineSets,omitempty" bson:"machineSets,omitempty"`
+	// +optional
+	StorageUsage *StorageUsage `json:"storageUsage,omitempty" bson:"storageUsage,omitempty"`
+	// +optional
+	ClusterNetwork *ClusterNetwork `json:"clusterNetwork,omitempty" bson:"clusterNetwork,omitempty"`
+	// NetworkInterfaces is the interface inventory of each node, taken from NodeNetworkState.
+	// +optional
+	NetworkInterfaces []NodeNetworkInterfaces `json:"networkInterfaces,omitempty" bson:"networkInterfaces,omitempty"`
+	// OLMOperators are the operators installed by OLM Subscriptions.
+	// +optional
+	OLMOperators []OLMOperator `json:"olmOperators,omitempty" bson:"olmOperators,omitempty"`
+	// +optional
+	CustomResourceDefinitions []CustomResourceDefinition `json:"customResourceDefinitions,omitempty" bson:"customResourceDefinitions,omitempty"`
+	// APIServices are the aggregated APIs of the cluster.
+	// +optional
+	APIServices []APIService `json:"apiServices,omitempty" bson:"apiServices,omitempty"`
+	// APIChanges are the latest changes detected in CustomResourceDefinitions and APIServices, oldest first.
+	// +optional
+	APIChanges []APIChange `json:"apiChanges,omitempty" bson:"apiChanges,omitempty"`
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" bson:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -104,16 +716,68 @@ func init() {
 func (s *ClusterInfoStatus) Normalize() {
 	sort.Strings(s.RouterLBAddresses)
 	sort.Strings(s.ApiServerAddresses)
-	sort.Strings(s.IdentityProviders)
 	sort.Strings(s.MutatingWebhooks)
 	sort.Strings(s.ValidatingWebhooks)
-	sort.Strings(s.Segments)
 
 	sort.Slice(s.NodeInfo, func(i, j int) bool {
 		return s.NodeInfo[i].Name < s.NodeInfo[j].Name
 	})
 
+	sort.Slice(s.IdentityProviders, func(i, j int) bool {
+		return s.IdentityProviders[i].Name < s.IdentityProviders[j].Name
+	})
+
 	sort.Slice(s.StorageProvisioners, func(i, j int) bool {
 		return s.StorageProvisioners[i].Name < s.StorageProvisioners[j].Name
 	})
+
+	sort.Slice(s.AdmissionWebhooks, func(i, j int) bool {
+		if s.AdmissionWebhooks[i].Configuration != s.AdmissionWebhooks[j].Configuration {
+			return s.AdmissionWebhooks[i].Configuration < s.AdmissionWebhooks[j].Configuration
+		}
+		return s.AdmissionWebhooks[i].Name < s.AdmissionWebhooks[j].Name
+	})
+
+	sort.Slice(s.Segments, func(i, j int) bool {
+		return s.Segments[i].Prefix < s.Segments[j].Prefix
+	})
+
+	sort.Slice(s.ClusterOperators, func(i, j int) bool {
+		return s.ClusterOperators[i].Name < s.ClusterOperators[j].Name
+	})
+
+	sort.Slice(s.IngressControllers, func(i, j int) bool {
+		return s.IngressControllers[i].Name < s.IngressControllers[j].Name
+	})
+
+	sort.Slice(s.NetworkInterfaces, func(i, j int) bool {
+		return s.NetworkInterfaces[i].Node < s.NetworkInterfaces[j].Node
+	})
+
+	sort.Slice(s.OLMOperators, func(i, j int) bool {
+		if s.OLMOperators[i].Namespace != s.OLMOperators[j].Namespace {
+			return s.OLMOperators[i].Namespace < s.OLMOperators[j].Namespace
+		}
+		return s.OLMOperators[i].Name < s.OLMOperators[j].Name
+	})
+
+	sort.Slice(s.CustomResourceDefinitions, func(i, j int) bool {
+		return s.CustomResourceDefinitions[i].Name < s.CustomResourceDefinitions[j].Name
+	})
+
+	sort.Slice(s.APIServices, func(i, j int) bool {
+		return s.APIServices[i].Name < s.APIServices[j].Name
+	})
+
+	sort.Slice(s.MachineConfigPools, func(i, j int) bool {
+		return s.MachineConfigPools[i].Name < s.MachineConfigPools[j].Name
+	})
+
+	sort.Slice(s.MachineSets, func(i, j int) bool {
+		return s.MachineSets[i].Name < s.MachineSets[j].Name
+	})
+
+	sort.Slice(s.Conditions, func(i, j int) bool {
+		return s.Conditions[i].Type < s.Conditions[j].Type
+	})
 }
@@ -21,9 +21,89 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIChange) DeepCopyInto(out *APIChange) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIChange.
+func (in *APIChange) DeepCopy() *APIChange {
+	if in == nil {
+		return nil
+	}
+	out := new(APIChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIService) DeepCopyInto(out *APIService) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIService.
+func (in *APIService) DeepCopy() *APIService {
+	if in == nil {
+		return nil
+	}
+	out := new(APIService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdmissionWebhook) DeepCopyInto(out *AdmissionWebhook) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]WebhookRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ServiceReady != nil {
+		in, out := &in.ServiceReady, &out.ServiceReady
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionWebhook.
+func (in *AdmissionWebhook) DeepCopy() *AdmissionWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(AdmissionWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CSIDriver) DeepCopyInto(out *CSIDriver) {
+	*out = *in
+	if in.VolumeLifecycleModes != nil {
+		in, out := &in.VolumeLifecycleModes, &out.VolumeLifecycleModes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CSIDriver.
+func (in *CSIDriver) DeepCopy() *CSIDriver {
+	if in == nil {
+		return nil
+	}
+	out := new(CSIDriver)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterDnsConfig) DeepCopyInto(out *ClusterDnsConfig) {
 	*out = *in
@@ -54,7 +134,7 @@ func (in *ClusterInfo) DeepCopyInto(out *ClusterInfo) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -111,6 +191,31 @@ func (in *ClusterInfoList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterInfoSpec) DeepCopyInto(out *ClusterInfoSpec) {
 	*out = *in
+	if in.HostedCluster != nil {
+		in, out := &in.HostedCluster, &out.HostedCluster
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Segments != nil {
+		in, out := &in.Segments, &out.Segments
+		*out = new(SegmentsSpec)
+		**out = **in
+	}
+	if in.ManagementCluster != nil {
+		in, out := &in.ManagementCluster, &out.ManagementCluster
+		*out = new(ManagementClusterSpec)
+		**out = **in
+	}
+	if in.NetBox != nil {
+		in, out := &in.NetBox, &out.NetBox
+		*out = new(NetBoxSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Workloads != nil {
+		in, out := &in.Workloads, &out.Workloads
+		*out = new(WorkloadsSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterInfoSpec.
@@ -127,7 +232,7 @@ func (in *ClusterInfoSpec) DeepCopy() *ClusterInfoSpec {
 func (in *ClusterInfoStatus) DeepCopyInto(out *ClusterInfoStatus) {
 	*out = *in
 	in.ClusterDnsConfig.DeepCopyInto(&out.ClusterDnsConfig)
-	out.ClusterResources = in.ClusterResources
+	in.ClusterResources.DeepCopyInto(&out.ClusterResources)
 	if in.NodeInfo != nil {
 		in, out := &in.NodeInfo, &out.NodeInfo
 		*out = make([]NodeInfo, len(*in))
@@ -143,15 +248,46 @@ func (in *ClusterInfoStatus) DeepCopyInto(out *ClusterInfoStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ClusterVersion != nil {
+		in, out := &in.ClusterVersion, &out.ClusterVersion
+		*out = new(ClusterVersionInfo)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Platform != nil {
+		in, out := &in.Platform, &out.Platform
+		*out = new(Platform)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterOperators != nil {
+		in, out := &in.ClusterOperators, &out.ClusterOperators
+		*out = make([]ClusterOperator, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PlatformHealthy != nil {
+		in, out := &in.PlatformHealthy, &out.PlatformHealthy
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IngressControllers != nil {
+		in, out := &in.IngressControllers, &out.IngressControllers
+		*out = make([]IngressController, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.IdentityProviders != nil {
 		in, out := &in.IdentityProviders, &out.IdentityProviders
-		*out = make([]string, len(*in))
+		*out = make([]IdentityProvider, len(*in))
 		copy(*out, *in)
 	}
 	if in.StorageProvisioners != nil {
 		in, out := &in.StorageProvisioners, &out.StorageProvisioners
 		*out = make([]StorageProvisioner, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.MutatingWebhooks != nil {
 		in, out := &in.MutatingWebhooks, &out.MutatingWebhooks
@@ -165,9 +301,96 @@ func (in *ClusterInfoStatus) DeepCopyInto(out *ClusterInfoStatus) {
 	}
 	if in.Segments != nil {
 		in, out := &in.Segments, &out.Segments
-		*out = make([]string, len(*in))
+		*out = make([]Segment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SegmentDrift != nil {
+		in, out := &in.SegmentDrift, &out.SegmentDrift
+		*out = new(SegmentDrift)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetBoxSync != nil {
+		in, out := &in.NetBoxSync, &out.NetBoxSync
+		*out = new(NetBoxSync)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdmissionWebhooks != nil {
+		in, out := &in.AdmissionWebhooks, &out.AdmissionWebhooks
+		*out = make([]AdmissionWebhook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Workloads != nil {
+		in, out := &in.Workloads, &out.Workloads
+		*out = new(Workloads)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.QuotaOvercommit != nil {
+		in, out := &in.QuotaOvercommit, &out.QuotaOvercommit
+		*out = new(QuotaOvercommit)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MachineConfigPools != nil {
+		in, out := &in.MachineConfigPools, &out.MachineConfigPools
+		*out = make([]MachineConfigPool, len(*in))
+		copy(*out, *in)
+	}
+	if in.MachineSets != nil {
+		in, out := &in.MachineSets, &out.MachineSets
+		*out = make([]MachineSet, len(*in))
+		copy(*out, *in)
+	}
+	if in.StorageUsage != nil {
+		in, out := &in.StorageUsage, &out.StorageUsage
+		*out = new(StorageUsage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterNetwork != nil {
+		in, out := &in.ClusterNetwork, &out.ClusterNetwork
+		*out = new(ClusterNetwork)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkInterfaces != nil {
+		in, out := &in.NetworkInterfaces, &out.NetworkInterfaces
+		*out = make([]NodeNetworkInterfaces, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OLMOperators != nil {
+		in, out := &in.OLMOperators, &out.OLMOperators
+		*out = make([]OLMOperator, len(*in))
+		copy(*out, *in)
+	}
+	if in.CustomResourceDefinitions != nil {
+		in, out := &in.CustomResourceDefinitions, &out.CustomResourceDefinitions
+		*out = make([]CustomResourceDefinition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.APIServices != nil {
+		in, out := &in.APIServices, &out.APIServices
+		*out = make([]APIService, len(*in))
 		copy(*out, *in)
 	}
+	if in.APIChanges != nil {
+		in, out := &in.APIChanges, &out.APIChanges
+		*out = make([]APIChange, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterInfoStatus.
@@ -180,9 +403,79 @@ func (in *ClusterInfoStatus) DeepCopy() *ClusterInfoStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterNetwork) DeepCopyInto(out *ClusterNetwork) {
+	*out = *in
+	if in.ClusterNetworks != nil {
+		in, out := &in.ClusterNetworks, &out.ClusterNetworks
+		*out = make([]ClusterNetworkEntry, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServiceNetworks != nil {
+		in, out := &in.ServiceNetworks, &out.ServiceNetworks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MachineNetworks != nil {
+		in, out := &in.MachineNetworks, &out.MachineNetworks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterNetwork.
+func (in *ClusterNetwork) DeepCopy() *ClusterNetwork {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterNetwork)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterNetworkEntry) DeepCopyInto(out *ClusterNetworkEntry) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterNetworkEntry.
+func (in *ClusterNetworkEntry) DeepCopy() *ClusterNetworkEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterNetworkEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterOperator) DeepCopyInto(out *ClusterOperator) {
+	*out = *in
+	if in.Versions != nil {
+		in, out := &in.Versions, &out.Versions
+		*out = make([]OperandVersion, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterOperator.
+func (in *ClusterOperator) DeepCopy() *ClusterOperator {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterOperator)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterResources) DeepCopyInto(out *ClusterResources) {
 	*out = *in
+	if in.Utilization != nil {
+		in, out := &in.Utilization, &out.Utilization
+		*out = make([]ResourceUtilization, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterResources.
@@ -196,31 +489,683 @@ func (in *ClusterResources) DeepCopy() *ClusterResources {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeInfo) DeepCopyInto(out *NodeInfo) {
+func (in *ClusterVersionInfo) DeepCopyInto(out *ClusterVersionInfo) {
+	*out = *in
+	if in.AvailableUpdates != nil {
+		in, out := &in.AvailableUpdates, &out.AvailableUpdates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]ClusterVersionUpdate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]OperatorCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterVersionInfo.
+func (in *ClusterVersionInfo) DeepCopy() *ClusterVersionInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterVersionInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterVersionUpdate) DeepCopyInto(out *ClusterVersionUpdate) {
 	*out = *in
+	in.StartedTime.DeepCopyInto(&out.StartedTime)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeInfo.
-func (in *NodeInfo) DeepCopy() *NodeInfo {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterVersionUpdate.
+func (in *ClusterVersionUpdate) DeepCopy() *ClusterVersionUpdate {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeInfo)
+	out := new(ClusterVersionUpdate)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *StorageProvisioner) DeepCopyInto(out *StorageProvisioner) {
+func (in *CustomResourceDefinition) DeepCopyInto(out *CustomResourceDefinition) {
 	*out = *in
+	if in.ServedVersions != nil {
+		in, out := &in.ServedVersions, &out.ServedVersions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageProvisioner.
-func (in *StorageProvisioner) DeepCopy() *StorageProvisioner {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomResourceDefinition.
+func (in *CustomResourceDefinition) DeepCopy() *CustomResourceDefinition {
 	if in == nil {
 		return nil
 	}
-	out := new(StorageProvisioner)
+	out := new(CustomResourceDefinition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IdentityProvider) DeepCopyInto(out *IdentityProvider) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdentityProvider.
+func (in *IdentityProvider) DeepCopy() *IdentityProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(IdentityProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressController) DeepCopyInto(out *IngressController) {
+	*out = *in
+	if in.LoadBalancerAddresses != nil {
+		in, out := &in.LoadBalancerAddresses, &out.LoadBalancerAddresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressController.
+func (in *IngressController) DeepCopy() *IngressController {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressController)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineConfigPool) DeepCopyInto(out *MachineConfigPool) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineConfigPool.
+func (in *MachineConfigPool) DeepCopy() *MachineConfigPool {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineConfigPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineSet) DeepCopyInto(out *MachineSet) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineSet.
+func (in *MachineSet) DeepCopy() *MachineSet {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagementClusterSpec) DeepCopyInto(out *ManagementClusterSpec) {
+	*out = *in
+	out.KubeconfigSecretRef = in.KubeconfigSecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementClusterSpec.
+func (in *ManagementClusterSpec) DeepCopy() *ManagementClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagementClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetBoxSpec) DeepCopyInto(out *NetBoxSpec) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(v1.SecretReference)
+		**out = **in
+	}
+	if in.WriteBack != nil {
+		in, out := &in.WriteBack, &out.WriteBack
+		*out = new(NetBoxWriteBackSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetBoxSpec.
+func (in *NetBoxSpec) DeepCopy() *NetBoxSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetBoxSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetBoxSync) DeepCopyInto(out *NetBoxSync) {
+	*out = *in
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetBoxSync.
+func (in *NetBoxSync) DeepCopy() *NetBoxSync {
+	if in == nil {
+		return nil
+	}
+	out := new(NetBoxSync)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetBoxWriteBackSpec) DeepCopyInto(out *NetBoxWriteBackSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetBoxWriteBackSpec.
+func (in *NetBoxWriteBackSpec) DeepCopy() *NetBoxWriteBackSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetBoxWriteBackSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkInterface) DeepCopyInto(out *NetworkInterface) {
+	*out = *in
+	if in.BondPorts != nil {
+		in, out := &in.BondPorts, &out.BondPorts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkInterface.
+func (in *NetworkInterface) DeepCopy() *NetworkInterface {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkInterface)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkRoute) DeepCopyInto(out *NetworkRoute) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkRoute.
+func (in *NetworkRoute) DeepCopy() *NetworkRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeInfo) DeepCopyInto(out *NodeInfo) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeInfo.
+func (in *NodeInfo) DeepCopy() *NodeInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeNetworkInterfaces) DeepCopyInto(out *NodeNetworkInterfaces) {
+	*out = *in
+	if in.Interfaces != nil {
+		in, out := &in.Interfaces, &out.Interfaces
+		*out = make([]NetworkInterface, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]NetworkRoute, len(*in))
+		copy(*out, *in)
+	}
+	if in.DefaultGateways != nil {
+		in, out := &in.DefaultGateways, &out.DefaultGateways
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeNetworkInterfaces.
+func (in *NodeNetworkInterfaces) DeepCopy() *NodeNetworkInterfaces {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeNetworkInterfaces)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OLMOperator) DeepCopyInto(out *OLMOperator) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OLMOperator.
+func (in *OLMOperator) DeepCopy() *OLMOperator {
+	if in == nil {
+		return nil
+	}
+	out := new(OLMOperator)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperandVersion) DeepCopyInto(out *OperandVersion) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperandVersion.
+func (in *OperandVersion) DeepCopy() *OperandVersion {
+	if in == nil {
+		return nil
+	}
+	out := new(OperandVersion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorCondition) DeepCopyInto(out *OperatorCondition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorCondition.
+func (in *OperatorCondition) DeepCopy() *OperatorCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OwnerWorkloads) DeepCopyInto(out *OwnerWorkloads) {
+	*out = *in
+	in.WorkloadCounts.DeepCopyInto(&out.WorkloadCounts)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OwnerWorkloads.
+func (in *OwnerWorkloads) DeepCopy() *OwnerWorkloads {
+	if in == nil {
+		return nil
+	}
+	out := new(OwnerWorkloads)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Platform) DeepCopyInto(out *Platform) {
+	*out = *in
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Platform.
+func (in *Platform) DeepCopy() *Platform {
+	if in == nil {
+		return nil
+	}
+	out := new(Platform)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QuotaOvercommit) DeepCopyInto(out *QuotaOvercommit) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ResourceOvercommit, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuotaOvercommit.
+func (in *QuotaOvercommit) DeepCopy() *QuotaOvercommit {
+	if in == nil {
+		return nil
+	}
+	out := new(QuotaOvercommit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceOvercommit) DeepCopyInto(out *ResourceOvercommit) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceOvercommit.
+func (in *ResourceOvercommit) DeepCopy() *ResourceOvercommit {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceOvercommit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceUtilization) DeepCopyInto(out *ResourceUtilization) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceUtilization.
+func (in *ResourceUtilization) DeepCopy() *ResourceUtilization {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceUtilization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Segment) DeepCopyInto(out *Segment) {
+	*out = *in
+	if in.Interfaces != nil {
+		in, out := &in.Interfaces, &out.Interfaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Segment.
+func (in *Segment) DeepCopy() *Segment {
+	if in == nil {
+		return nil
+	}
+	out := new(Segment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SegmentDrift) DeepCopyInto(out *SegmentDrift) {
+	*out = *in
+	if in.Unregistered != nil {
+		in, out := &in.Unregistered, &out.Unregistered
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Unused != nil {
+		in, out := &in.Unused, &out.Unused
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SegmentDrift.
+func (in *SegmentDrift) DeepCopy() *SegmentDrift {
+	if in == nil {
+		return nil
+	}
+	out := new(SegmentDrift)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SegmentsSpec) DeepCopyInto(out *SegmentsSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SegmentsSpec.
+func (in *SegmentsSpec) DeepCopy() *SegmentsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SegmentsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageClassUsage) DeepCopyInto(out *StorageClassUsage) {
+	*out = *in
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Claims != nil {
+		in, out := &in.Claims, &out.Claims
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageClassUsage.
+func (in *StorageClassUsage) DeepCopy() *StorageClassUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageClassUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageProvisioner) DeepCopyInto(out *StorageProvisioner) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.CSIDriver != nil {
+		in, out := &in.CSIDriver, &out.CSIDriver
+		*out = new(CSIDriver)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageProvisioner.
+func (in *StorageProvisioner) DeepCopy() *StorageProvisioner {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageProvisioner)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageUsage) DeepCopyInto(out *StorageUsage) {
+	*out = *in
+	if in.StorageClasses != nil {
+		in, out := &in.StorageClasses, &out.StorageClasses
+		*out = make([]StorageClassUsage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageUsage.
+func (in *StorageUsage) DeepCopy() *StorageUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookRule) DeepCopyInto(out *WebhookRule) {
+	*out = *in
+	if in.Operations != nil {
+		in, out := &in.Operations, &out.Operations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.APIGroups != nil {
+		in, out := &in.APIGroups, &out.APIGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.APIVersions != nil {
+		in, out := &in.APIVersions, &out.APIVersions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookRule.
+func (in *WebhookRule) DeepCopy() *WebhookRule {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadCounts) DeepCopyInto(out *WorkloadCounts) {
+	*out = *in
+	if in.Pods != nil {
+		in, out := &in.Pods, &out.Pods
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadCounts.
+func (in *WorkloadCounts) DeepCopy() *WorkloadCounts {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadCounts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Workloads) DeepCopyInto(out *Workloads) {
+	*out = *in
+	in.WorkloadCounts.DeepCopyInto(&out.WorkloadCounts)
+	if in.Owners != nil {
+		in, out := &in.Owners, &out.Owners
+		*out = make([]OwnerWorkloads, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Workloads.
+func (in *Workloads) DeepCopy() *Workloads {
+	if in == nil {
+		return nil
+	}
+	out := new(Workloads)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadsSpec) DeepCopyInto(out *WorkloadsSpec) {
+	*out = *in
+	if in.SystemNamespacePatterns != nil {
+		in, out := &in.SystemNamespacePatterns, &out.SystemNamespacePatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadsSpec.
+func (in *WorkloadsSpec) DeepCopy() *WorkloadsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadsSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -17,12 +17,18 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
+	"errors"
 	"flag"
 	"os"
 	"path/filepath"
+	"time"
 
 	configv1 "github.com/openshift/api/config/v1"
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	mcfgv1 "github.com/openshift/api/machineconfiguration/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
 	routev1 "github.com/openshift/api/route/v1"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
@@ -30,13 +36,19 @@ import (
 	nmstatev1 "github.com/dana-team/axiom-operator/api/nmstate/v1"
 	axiomv1alpha1 "github.com/dana-team/axiom-operator/api/v1alpha1"
 	"github.com/dana-team/axiom-operator/internal/controller"
+	"github.com/dana-team/axiom-operator/internal/db"
+	"github.com/dana-team/axiom-operator/internal/overlap"
 	nmstatev1alpha1 "github.com/nmstate/kubernetes-nmstate/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/discovery"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
@@ -57,6 +69,9 @@ func init() {
 	utilruntime.Must(axiomv1alpha1.AddToScheme(scheme))
 	utilruntime.Must(routev1.AddToScheme(scheme))
 	utilruntime.Must(configv1.AddToScheme(scheme))
+	utilruntime.Must(operatorv1.AddToScheme(scheme))
+	utilruntime.Must(machinev1beta1.AddToScheme(scheme))
+	utilruntime.Must(mcfgv1.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
 
@@ -69,6 +84,8 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var overlapDetectionInterval time.Duration
+	var resyncInterval time.Duration
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -87,6 +104,12 @@ func main() {
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.DurationVar(&overlapDetectionInterval, "overlap-detection-interval", 0,
+		"If set, the leader periodically detects IP overlaps between all the clusters stored in MongoDB "+
+			"and stores the report in the overlapReport collection. Intended for the hub cluster only.")
+	flag.DurationVar(&resyncInterval, "resync-interval", 10*time.Minute,
+		"The interval at which the ClusterInfo status is collected again, refreshing the facts that change "+
+			"without a spec change, e.g. the resource usage.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -184,6 +207,13 @@ func main() {
 		})
 	}
 
+	// The referenced Secrets are only watched and readable in the operator namespace.
+	namespace := os.Getenv("POD_NAMESPACE")
+	if namespace == "" {
+		setupLog.Error(errors.New("POD_NAMESPACE is not set"), "unable to determine the operator namespace")
+		os.Exit(1)
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
 		Metrics:                metricsServerOptions,
@@ -191,6 +221,11 @@ func main() {
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "8418f2e4.dana.io",
+		Cache:                  cacheOptions(namespace),
+		// Only a few kube-system ConfigMaps are read, so they are not worth caching cluster-wide.
+		Client: client.Options{
+			Cache: &client.CacheOptions{DisableFor: []client.Object{&corev1.ConfigMap{}}},
+		},
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -208,15 +243,43 @@ func main() {
 		os.Exit(1)
 	}
 
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create discovery client")
+		os.Exit(1)
+	}
+
 	if err = (&controller.ClusterInfoReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:         mgr.GetClient(),
+		Scheme:         mgr.GetScheme(),
+		APIReader:      mgr.GetAPIReader(),
+		RESTConfig:     mgr.GetConfig(),
+		Discovery:      discoveryClient,
+		ResyncInterval: resyncInterval,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ClusterInfo")
 		os.Exit(1)
 	}
 	// +kubebuilder:scaffold:builder
 
+	if overlapDetectionInterval > 0 {
+		setupLog.Info("Adding cluster overlap detector to manager", "interval", overlapDetectionInterval)
+		overlapLogger := ctrl.Log.WithName("overlap")
+		if err := mgr.Add(&overlap.Detector{
+			Logger:   overlapLogger,
+			Interval: overlapDetectionInterval,
+			Load: func(ctx context.Context) ([]axiomv1alpha1.ClusterInfoStatus, error) {
+				return db.ListClusterInfosFromMongo(ctx, overlapLogger)
+			},
+			Store: func(ctx context.Context, report overlap.Report) error {
+				return db.InsertOverlapReportToMongo(ctx, report)
+			},
+		}); err != nil {
+			setupLog.Error(err, "unable to add cluster overlap detector to manager")
+			os.Exit(1)
+		}
+	}
+
 	if metricsCertWatcher != nil {
 		setupLog.Info("Adding metrics certificate watcher to manager")
 		if err := mgr.Add(metricsCertWatcher); err != nil {
@@ -248,3 +311,13 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// cacheOptions limits the Secret cache, which only holds the metadata of the watched Secrets, to the operator
+// namespace, where the referenced Secrets are read from.
+func cacheOptions(namespace string) cache.Options {
+	return cache.Options{
+		ByObject: map[client.Object]cache.ByObject{
+			&corev1.Secret{}: {Namespaces: map[string]cache.Config{namespace: {}}},
+		},
+	}
+}
@@ -19,21 +19,45 @@ package controller
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/dana-team/axiom-operator/internal/db"
 
 	axiomv1alpha1 "github.com/dana-team/axiom-operator/api/v1alpha1"
+	"github.com/dana-team/axiom-operator/internal/controller/resources"
 	"github.com/dana-team/axiom-operator/internal/controller/status"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 // ClusterInfoReconciler reconciles a ClusterInfo object
 type ClusterInfoReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+	// APIReader reads the objects that are not cached, such as the referenced Secrets, from the API server.
+	APIReader client.Reader
+	// RESTConfig is the configuration of the connection to the API server.
+	RESTConfig *rest.Config
+	// Discovery reads the server version of plain Kubernetes clusters.
+	Discovery discovery.ServerVersionInterface
+	// ResyncInterval is the interval at which the status is collected again. Only spec changes trigger a reconcile,
+	// so the facts that change on their own, e.g. the resource usage, are refreshed on this interval.
+	ResyncInterval time.Duration
+
+	// netBoxClients keeps the NetBox clients across reconciles, so that their rate limit holds between reconciles.
+	netBoxClients resources.NetBoxClientCache
+	// managementClients keeps the management cluster clients across reconciles, so that they are not rebuilt on
+	// every reconcile.
+	managementClients resources.ManagementClientCache
 }
 
 // +kubebuilder:rbac:groups=axiom.dana.io,resources=clusterinfo,verbs=get;list;watch;create;update;patch;delete
@@ -41,15 +65,35 @@ type ClusterInfoReconciler struct {
 // +kubebuilder:rbac:groups=axiom.dana.io,resources=clusterinfo/finalizers,verbs=update
 // +kubebuilder:rbac:groups=axiom.dana.io,resources=clusterversions,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",namespace=system,resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get
+// +kubebuilder:rbac:groups=apps,resources=daemonsets;deployments;statefulsets,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;create;delete
 // +kubebuilder:rbac:groups="",resources=pods/log,verbs=get;list;watch;create
 // +kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;list;watch
 // +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=mutatingwebhookconfigurations,verbs=get;list;watch
 // +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=validatingwebhookconfigurations,verbs=get;list;watch
 // +kubebuilder:rbac:groups=config.openshift.io,resources=clusterversions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=config.openshift.io,resources=clusteroperators,verbs=get;list;watch
+// +kubebuilder:rbac:groups=operators.coreos.com,resources=subscriptions;clusterserviceversions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apiregistration.k8s.io,resources=apiservices,verbs=get;list;watch
+// +kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=get;list;watch
 // +kubebuilder:rbac:groups=config.openshift.io,resources=oauths,verbs=get;list;watch
+// +kubebuilder:rbac:groups=config.openshift.io,resources=networks,verbs=get;list;watch
+// +kubebuilder:rbac:groups=config.openshift.io,resources=infrastructures,verbs=get;list;watch
+// +kubebuilder:rbac:groups=operator.openshift.io,resources=ingresscontrollers,verbs=get;list;watch
+// +kubebuilder:rbac:groups=machineconfiguration.openshift.io,resources=machineconfigpools,verbs=get;list;watch
+// +kubebuilder:rbac:groups=machine.openshift.io,resources=machinesets;machines,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch
 // +kubebuilder:rbac:groups=nmstate.io,resources=nodenetworkconfigurationpolicies,verbs=get;list;watch
 // +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch
+// +kubebuilder:rbac:groups=storage.k8s.io,resources=csidrivers,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=persistentvolumes,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=resourcequotas;limitranges,verbs=get;list;watch
+// +kubebuilder:rbac:groups=metrics.k8s.io,resources=nodes,verbs=get;list
 // +kubebuilder:rbac:groups=nmstate.io,resources=nodenetworkstates,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -63,22 +107,54 @@ func (r *ClusterInfoReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
-	if err := status.UpdateClusterInfoStatus(ctx, logger, *clusterInfo, r.Client); err != nil {
+	updatedClusterInfo, err := status.UpdateClusterInfoStatus(ctx, logger, *clusterInfo, status.Clients{
+		Client:            r.Client,
+		APIReader:         r.APIReader,
+		RESTConfig:        r.RESTConfig,
+		Discovery:         r.Discovery,
+		NetBoxClients:     &r.netBoxClients,
+		ManagementClients: &r.managementClients,
+	})
+	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("Failed to update ClusterInfo status %s", err.Error())
 	}
 	logger.Info("ClusterInfo status updated successfully")
 
 	go func(clusterInfo axiomv1alpha1.ClusterInfo) {
 		db.InsertClusterInfoToMongo(logger, clusterInfo)
-	}(*clusterInfo)
+	}(updatedClusterInfo)
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: r.ResyncInterval}, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ClusterInfoReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&axiomv1alpha1.ClusterInfo{}).
+		For(&axiomv1alpha1.ClusterInfo{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.findClusterInfosForSecret), builder.OnlyMetadata).
 		Named("clusterinfo").
 		Complete(r)
 }
+
+// findClusterInfosForSecret maps a Secret to the ClusterInfo objects referencing it as their NetBox configuration
+// or management cluster kubeconfig, so that rotated credentials are picked up without restarting the operator.
+// Only the metadata of the Secrets is watched, their data is read through the APIReader.
+func (r *ClusterInfoReconciler) findClusterInfosForSecret(ctx context.Context, secret client.Object) []reconcile.Request {
+	clusterInfoList := &axiomv1alpha1.ClusterInfoList{}
+	if err := r.List(ctx, clusterInfoList); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list ClusterInfo")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range clusterInfoList.Items {
+		ci := &clusterInfoList.Items[i]
+		for _, ref := range []*corev1.SecretReference{resources.NetBoxSecretRef(ci), resources.ManagementKubeconfigSecretRef(ci)} {
+			if ref != nil && ref.Name == secret.GetName() && ref.Namespace == secret.GetNamespace() {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(ci)})
+				break
+			}
+		}
+	}
+	return requests
+}
@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"net"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -29,29 +32,46 @@ func FormatMiB(q *resource.Quantity) string {
 	return fmt.Sprintf("%.0fMi", mib)
 }
 
+// CreateSegmentFromIPAndPrefix returns the network, in CIDR notation, of an IPv4 or IPv6 address with the given prefix length.
 func CreateSegmentFromIPAndPrefix(ip string, prefix int) (string, error) {
 	parsedIp := net.ParseIP(ip)
 	if parsedIp == nil {
 		return "", fmt.Errorf("failed to parse IP address %s", ip)
 	}
 
-	parsedIp = parsedIp.To4()
-	if parsedIp == nil {
-		return "", fmt.Errorf("failed to convert IP address %s to IPv4", ip)
+	bits := net.IPv6len * 8
+	if ipv4 := parsedIp.To4(); ipv4 != nil {
+		parsedIp, bits = ipv4, net.IPv4len*8
+	}
+	if prefix < 0 || prefix > bits {
+		return "", fmt.Errorf("invalid prefix length %d for IP address %s", prefix, ip)
 	}
 
-	mask := net.CIDRMask(prefix, 32)
+	mask := net.CIDRMask(prefix, bits)
 	network := parsedIp.Mask(mask)
 	ipNet := net.IPNet{IP: network, Mask: mask}
 
 	return ipNet.String(), nil
 }
 
+// SegmentIPFamily returns the IP family of a segment in CIDR notation, or an empty family if it cannot be parsed.
+func SegmentIPFamily(segment string) corev1.IPFamily {
+	ip, _, err := net.ParseCIDR(segment)
+	switch {
+	case err != nil:
+		return ""
+	case ip.To4() != nil:
+		return corev1.IPv4Protocol
+	default:
+		return corev1.IPv6Protocol
+	}
+}
+
 func FilterUniqueStrings(slice []string) []string {
 	seen := make(map[string]struct{}, len(slice))
 	out := make([]string, 0, len(slice))
 	for _, s := range slice {
-		if _, ok := seen[s]; !ok {
+		if _, ok := seen[s]; ok {
 			continue
 		}
 		seen[s] = struct{}{}
@@ -60,3 +80,9 @@ func FilterUniqueStrings(slice []string) []string {
 
 	return out
 }
+
+// IsAPINotAvailable reports whether an error was returned because the requested kind is not served by the cluster,
+// such as OpenShift APIs on a vanilla Kubernetes cluster, or is not registered in the client scheme.
+func IsAPINotAvailable(err error) bool {
+	return meta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err)
+}
@@ -0,0 +1,12 @@
+package common
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FilterUniqueStrings", func() {
+	It("should drop the duplicates and keep the first occurrences in order", func() {
+		Expect(FilterUniqueStrings([]string{"b", "a", "b", "c", "a"})).To(Equal([]string{"b", "a", "c"}))
+	})
+})
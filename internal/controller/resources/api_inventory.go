@@ -0,0 +1,194 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+
+	"github.com/dana-team/axiom-operator/api/v1alpha1"
+	"github.com/dana-team/axiom-operator/internal/controller/common"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	CustomResourceDefinitionResource = "CustomResourceDefinition"
+	APIServiceResource               = "APIService"
+
+	// MaxAPIChanges is the number of API changes kept in the status.
+	MaxAPIChanges = 50
+)
+
+// CustomResourceDefinitionListGVK and APIServiceListGVK are the kinds of the API lists, read as unstructured objects.
+var (
+	CustomResourceDefinitionListGVK = schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinitionList"}
+	APIServiceListGVK               = schema.GroupVersionKind{Group: "apiregistration.k8s.io", Version: "v1", Kind: "APIServiceList"}
+)
+
+// GetCustomResourceDefinitions retrieves the group, kind, scope, served versions and storage version of the CRDs.
+// The CRDs hold their whole OpenAPI schemas, so they are listed through apiReader instead of being cached.
+func GetCustomResourceDefinitions(ctx context.Context, logger logr.Logger, apiReader client.Reader) ([]v1alpha1.CustomResourceDefinition, error) {
+	crdList := &unstructured.UnstructuredList{}
+	crdList.SetGroupVersionKind(CustomResourceDefinitionListGVK)
+	if err := apiReader.List(ctx, crdList); err != nil {
+		if common.IsAPINotAvailable(err) {
+			logger.Info("CustomResourceDefinitions are not available, skipping")
+			return nil, nil
+		}
+		logger.Error(err, "Failed to list CustomResourceDefinitions")
+		return nil, err
+	}
+
+	crds := make([]v1alpha1.CustomResourceDefinition, 0, len(crdList.Items))
+	for _, item := range crdList.Items {
+		crds = append(crds, customResourceDefinitionFromObject(item))
+	}
+	sort.Slice(crds, func(i, j int) bool {
+		return crds[i].Name < crds[j].Name
+	})
+	return crds, nil
+}
+
+// customResourceDefinitionFromObject reads the details of an unstructured CRD.
+func customResourceDefinitionFromObject(item unstructured.Unstructured) v1alpha1.CustomResourceDefinition {
+	crd := v1alpha1.CustomResourceDefinition{Name: item.GetName()}
+	crd.Group, _, _ = unstructured.NestedString(item.Object, "spec", "group")
+	crd.Kind, _, _ = unstructured.NestedString(item.Object, "spec", "names", "kind")
+	crd.Scope, _, _ = unstructured.NestedString(item.Object, "spec", "scope")
+
+	versions, _, _ := unstructured.NestedSlice(item.Object, "spec", "versions")
+	for _, v := range versions {
+		version, ok := v.(map[string]any)
+		if !ok {
+			continue
+		}
+		name, _, _ := unstructured.NestedString(version, "name")
+		if served, _, _ := unstructured.NestedBool(version, "served"); served {
+			crd.ServedVersions = append(crd.ServedVersions, name)
+		}
+		if storage, _, _ := unstructured.NestedBool(version, "storage"); storage {
+			crd.StorageVersion = name
+		}
+	}
+	sort.Strings(crd.ServedVersions)
+	return crd
+}
+
+// GetAPIServices retrieves the aggregated APIServices, i.e. the ones served by an in-cluster service, and their
+// availability. APIServices served locally by the API server are skipped. Like the CRDs, they are listed through
+// apiReader instead of being cached.
+func GetAPIServices(ctx context.Context, logger logr.Logger, apiReader client.Reader) ([]v1alpha1.APIService, error) {
+	apiServiceList := &unstructured.UnstructuredList{}
+	apiServiceList.SetGroupVersionKind(APIServiceListGVK)
+	if err := apiReader.List(ctx, apiServiceList); err != nil {
+		if common.IsAPINotAvailable(err) {
+			logger.Info("APIServices are not available, skipping")
+			return nil, nil
+		}
+		logger.Error(err, "Failed to list APIServices")
+		return nil, err
+	}
+
+	var apiServices []v1alpha1.APIService
+	for _, item := range apiServiceList.Items {
+		service, found, _ := unstructured.NestedStringMap(item.Object, "spec", "service")
+		if !found {
+			continue
+		}
+		apiService := v1alpha1.APIService{
+			Name:      item.GetName(),
+			Service:   fmt.Sprintf("%s/%s", service["namespace"], service["name"]),
+			Available: string(metav1.ConditionUnknown),
+		}
+		conditions, _, _ := unstructured.NestedSlice(item.Object, "status", "conditions")
+		for _, c := range conditions {
+			condition, ok := c.(map[string]any)
+			if !ok || condition["type"] != "Available" {
+				continue
+			}
+			apiService.Available, _, _ = unstructured.NestedString(condition, "status")
+			if apiService.Available != string(metav1.ConditionTrue) {
+				apiService.Message, _, _ = unstructured.NestedString(condition, "message")
+			}
+		}
+		apiServices = append(apiServices, apiService)
+	}
+	sort.Slice(apiServices, func(i, j int) bool {
+		return apiServices[i].Name < apiServices[j].Name
+	})
+	return apiServices, nil
+}
+
+// DetectAPIChanges compares the collected CRDs and APIServices to the previous status and appends the changes,
+// dated now, to the previous API changes, keeping the latest MaxAPIChanges. Nothing is detected when either the
+// previous status or the collection has no CRDs, e.g. on the first collection.
+func DetectAPIChanges(previous v1alpha1.ClusterInfoStatus, crds []v1alpha1.CustomResourceDefinition, apiServices []v1alpha1.APIService, now metav1.Time) []v1alpha1.APIChange {
+	changes := slices.Clone(previous.APIChanges)
+	if len(previous.CustomResourceDefinitions) == 0 || len(crds) == 0 {
+		return changes
+	}
+
+	record := func(resource, name, change string) {
+		changes = append(changes, v1alpha1.APIChange{Time: now, Resource: resource, Name: name, Change: change})
+	}
+
+	previousCRDs := make(map[string]v1alpha1.CustomResourceDefinition, len(previous.CustomResourceDefinitions))
+	for _, crd := range previous.CustomResourceDefinitions {
+		previousCRDs[crd.Name] = crd
+	}
+	for _, crd := range crds {
+		previousCRD, ok := previousCRDs[crd.Name]
+		delete(previousCRDs, crd.Name)
+		if !ok {
+			record(CustomResourceDefinitionResource, crd.Name, fmt.Sprintf("added with versions %v", crd.ServedVersions))
+			continue
+		}
+		for _, version := range previousCRD.ServedVersions {
+			if !slices.Contains(crd.ServedVersions, version) {
+				record(CustomResourceDefinitionResource, crd.Name, fmt.Sprintf("version %s removed", version))
+			}
+		}
+		for _, version := range crd.ServedVersions {
+			if !slices.Contains(previousCRD.ServedVersions, version) {
+				record(CustomResourceDefinitionResource, crd.Name, fmt.Sprintf("version %s added", version))
+			}
+		}
+		if crd.StorageVersion != previousCRD.StorageVersion {
+			record(CustomResourceDefinitionResource, crd.Name,
+				fmt.Sprintf("storage version changed from %s to %s", previousCRD.StorageVersion, crd.StorageVersion))
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(previousCRDs)) {
+		record(CustomResourceDefinitionResource, name, "removed")
+	}
+
+	previousAPIServices := make(map[string]v1alpha1.APIService, len(previous.APIServices))
+	for _, apiService := range previous.APIServices {
+		previousAPIServices[apiService.Name] = apiService
+	}
+	for _, apiService := range apiServices {
+		previousAPIService, ok := previousAPIServices[apiService.Name]
+		delete(previousAPIServices, apiService.Name)
+		switch {
+		case !ok:
+			record(APIServiceResource, apiService.Name, fmt.Sprintf("added, served by %s", apiService.Service))
+		case apiService.Available != previousAPIService.Available:
+			record(APIServiceResource, apiService.Name,
+				fmt.Sprintf("availability changed from %s to %s", previousAPIService.Available, apiService.Available))
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(previousAPIServices)) {
+		record(APIServiceResource, name, "removed")
+	}
+
+	if len(changes) > MaxAPIChanges {
+		changes = changes[len(changes)-MaxAPIChanges:]
+	}
+	return changes
+}
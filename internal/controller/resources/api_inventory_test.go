@@ -0,0 +1,32 @@
+package resources
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/dana-team/axiom-operator/api/v1alpha1"
+)
+
+var _ = Describe("APIChanges", func() {
+	crd := func(name string, servedVersions ...string) v1alpha1.CustomResourceDefinition {
+		return v1alpha1.CustomResourceDefinition{Name: name, ServedVersions: servedVersions, StorageVersion: servedVersions[0]}
+	}
+
+	It("should record a dropped CRD version", func() {
+		previous := v1alpha1.ClusterInfoStatus{
+			CustomResourceDefinitions: []v1alpha1.CustomResourceDefinition{crd("widgets.example.com", "v1", "v1beta1")},
+		}
+		crds := []v1alpha1.CustomResourceDefinition{crd("widgets.example.com", "v1")}
+
+		changes := DetectAPIChanges(previous, crds, nil, metav1.Now())
+		Expect(changes).To(HaveLen(1))
+		Expect(changes[0].Resource).To(Equal(CustomResourceDefinitionResource))
+		Expect(changes[0].Change).To(Equal("version v1beta1 removed"))
+	})
+
+	It("should not record changes on the first collection", func() {
+		crds := []v1alpha1.CustomResourceDefinition{crd("widgets.example.com", "v1")}
+		Expect(DetectAPIChanges(v1alpha1.ClusterInfoStatus{}, crds, nil, metav1.Now())).To(BeEmpty())
+	})
+})
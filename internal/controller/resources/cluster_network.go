@@ -0,0 +1,248 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dana-team/axiom-operator/api/v1alpha1"
+	"github.com/dana-team/axiom-operator/internal/controller/common"
+	"github.com/go-logr/logr"
+	configv1 "github.com/openshift/api/config/v1"
+	"gopkg.in/yaml.v3"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	KubeSystemNamespace = "kube-system"
+
+	InstallConfigName = "cluster-config-v1"
+	InstallConfigKey  = "install-config"
+
+	KubeadmConfigName            = "kubeadm-config"
+	KubeadmClusterConfigKey      = "ClusterConfiguration"
+	KubeProxyConfigName          = "kube-proxy"
+	KubeProxyConfigKey           = "config.conf"
+	networkConfigSourceOpenShift = "network.config.openshift.io/cluster"
+)
+
+// networkPluginDaemonSets maps the DaemonSets deployed by well-known network plugins to the plugin name.
+var networkPluginDaemonSets = map[string]string{
+	"calico-node":     "Calico",
+	"canal":           "Canal",
+	"cilium":          "Cilium",
+	"kube-flannel-ds": "Flannel",
+	"weave-net":       "Weave",
+	"antrea-agent":    "Antrea",
+	"kube-router":     "KubeRouter",
+	"ovnkube-node":    "OVNKubernetes",
+}
+
+// installConfig is the part of the OpenShift install-config holding the machine networks.
+type installConfig struct {
+	Networking struct {
+		MachineNetwork []struct {
+			CIDR string `yaml:"cidr"`
+		} `yaml:"machineNetwork"`
+	} `yaml:"networking"`
+}
+
+// kubeadmClusterConfiguration is the part of the kubeadm ClusterConfiguration holding the cluster networks.
+type kubeadmClusterConfiguration struct {
+	ClusterName string `yaml:"clusterName"`
+	Networking  struct {
+		PodSubnet     string `yaml:"podSubnet"`
+		ServiceSubnet string `yaml:"serviceSubnet"`
+	} `yaml:"networking"`
+}
+
+// kubeProxyConfiguration is the part of the kube-proxy configuration holding the pod networks.
+type kubeProxyConfiguration struct {
+	ClusterCIDR string `yaml:"clusterCIDR"`
+}
+
+// GetClusterNetwork retrieves the network plugin and the pod, service and machine networks of the cluster.
+// On OpenShift they are read from the cluster Network configuration and the install-config. Otherwise, they are read
+// from the kubeadm and kube-proxy ConfigMaps, falling back to the pod CIDRs allocated to the nodes.
+func GetClusterNetwork(ctx context.Context, logger logr.Logger, k8sClient client.Client, nodes []corev1.Node) (v1alpha1.ClusterNetwork, error) {
+	network := &configv1.Network{}
+	err := k8sClient.Get(ctx, client.ObjectKey{Name: "cluster"}, network)
+	switch {
+	case err == nil:
+		return getOpenShiftClusterNetwork(ctx, logger, k8sClient, network)
+	case common.IsAPINotAvailable(err) || apierrors.IsNotFound(err):
+		return getKubernetesClusterNetwork(ctx, logger, k8sClient, nodes)
+	default:
+		logger.Error(err, "Failed to get cluster Network configuration")
+		return v1alpha1.ClusterNetwork{}, err
+	}
+}
+
+// getOpenShiftClusterNetwork converts the OpenShift cluster Network configuration, preferring its status
+// over its spec, and adds the machine networks from the install-config when available.
+func getOpenShiftClusterNetwork(ctx context.Context, logger logr.Logger, k8sClient client.Client, network *configv1.Network) (v1alpha1.ClusterNetwork, error) {
+	clusterNetwork := v1alpha1.ClusterNetwork{
+		NetworkType:     network.Status.NetworkType,
+		ServiceNetworks: network.Status.ServiceNetwork,
+		MTU:             network.Status.ClusterNetworkMTU,
+		Source:          networkConfigSourceOpenShift,
+	}
+	entries := network.Status.ClusterNetwork
+	if len(entries) == 0 {
+		entries = network.Spec.ClusterNetwork
+	}
+	for _, entry := range entries {
+		clusterNetwork.ClusterNetworks = append(clusterNetwork.ClusterNetworks, v1alpha1.ClusterNetworkEntry{CIDR: entry.CIDR, HostPrefix: int(entry.HostPrefix)})
+	}
+	if clusterNetwork.NetworkType == "" {
+		clusterNetwork.NetworkType = network.Spec.NetworkType
+	}
+	if len(clusterNetwork.ServiceNetworks) == 0 {
+		clusterNetwork.ServiceNetworks = network.Spec.ServiceNetwork
+	}
+
+	configMap := &corev1.ConfigMap{}
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: InstallConfigName, Namespace: KubeSystemNamespace}, configMap); err != nil {
+		if !apierrors.IsNotFound(err) {
+			logger.Error(err, "Failed to get install-config")
+			return v1alpha1.ClusterNetwork{}, err
+		}
+		logger.Info("No install-config found, skipping machine networks")
+		return clusterNetwork, nil
+	}
+
+	var config installConfig
+	if err := yaml.Unmarshal([]byte(configMap.Data[InstallConfigKey]), &config); err != nil {
+		logger.Error(err, "Failed to unmarshal install-config")
+		return v1alpha1.ClusterNetwork{}, err
+	}
+	for _, machineNetwork := range config.Networking.MachineNetwork {
+		clusterNetwork.MachineNetworks = append(clusterNetwork.MachineNetworks, machineNetwork.CIDR)
+	}
+	return clusterNetwork, nil
+}
+
+// getKubernetesClusterNetwork reads the pod and service networks from the kubeadm ClusterConfiguration, the pod
+// networks from the kube-proxy configuration when kubeadm has none, and finally from the pod CIDRs of the nodes.
+// The network plugin is detected from the DaemonSets of well-known plugins.
+func getKubernetesClusterNetwork(ctx context.Context, logger logr.Logger, k8sClient client.Client, nodes []corev1.Node) (v1alpha1.ClusterNetwork, error) {
+	var clusterNetwork v1alpha1.ClusterNetwork
+	var sources []string
+
+	config, err := getKubeadmClusterConfiguration(ctx, k8sClient)
+	if err != nil {
+		logger.Error(err, "Failed to get kubeadm configuration")
+		return v1alpha1.ClusterNetwork{}, err
+	}
+	if config.Networking.PodSubnet != "" || config.Networking.ServiceSubnet != "" {
+		for _, cidr := range splitCIDRs(config.Networking.PodSubnet) {
+			clusterNetwork.ClusterNetworks = append(clusterNetwork.ClusterNetworks, v1alpha1.ClusterNetworkEntry{CIDR: cidr})
+		}
+		clusterNetwork.ServiceNetworks = splitCIDRs(config.Networking.ServiceSubnet)
+		sources = append(sources, KubeSystemNamespace+"/"+KubeadmConfigName)
+	}
+
+	if len(clusterNetwork.ClusterNetworks) == 0 {
+		kubeProxyConfig, err := getConfigMapData(ctx, k8sClient, KubeProxyConfigName, KubeProxyConfigKey)
+		if err != nil {
+			logger.Error(err, "Failed to get kube-proxy configuration")
+			return v1alpha1.ClusterNetwork{}, err
+		}
+		var config kubeProxyConfiguration
+		if err := yaml.Unmarshal([]byte(kubeProxyConfig), &config); err != nil {
+			logger.Error(err, "Failed to unmarshal kube-proxy configuration")
+			return v1alpha1.ClusterNetwork{}, err
+		}
+		for _, cidr := range splitCIDRs(config.ClusterCIDR) {
+			clusterNetwork.ClusterNetworks = append(clusterNetwork.ClusterNetworks, v1alpha1.ClusterNetworkEntry{CIDR: cidr})
+		}
+		if len(clusterNetwork.ClusterNetworks) > 0 {
+			sources = append(sources, KubeSystemNamespace+"/"+KubeProxyConfigName)
+		}
+	}
+
+	if len(clusterNetwork.ClusterNetworks) == 0 {
+		var podCIDRs []string
+		for _, node := range nodes {
+			podCIDRs = append(podCIDRs, node.Spec.PodCIDRs...)
+		}
+		podCIDRs = common.FilterUniqueStrings(podCIDRs)
+		sort.Strings(podCIDRs)
+		for _, cidr := range podCIDRs {
+			clusterNetwork.ClusterNetworks = append(clusterNetwork.ClusterNetworks, v1alpha1.ClusterNetworkEntry{CIDR: cidr})
+		}
+		if len(podCIDRs) > 0 {
+			sources = append(sources, "node podCIDRs")
+		}
+	}
+
+	networkType, err := detectNetworkPlugin(ctx, k8sClient)
+	if err != nil {
+		logger.Error(err, "Failed to detect the network plugin")
+		return v1alpha1.ClusterNetwork{}, err
+	}
+	clusterNetwork.NetworkType = networkType
+	clusterNetwork.Source = strings.Join(sources, ", ")
+
+	logger.Info(fmt.Sprintf("Found %d cluster networks from %q", len(clusterNetwork.ClusterNetworks), clusterNetwork.Source))
+	return clusterNetwork, nil
+}
+
+// getKubeadmClusterConfiguration returns the kubeadm ClusterConfiguration, which is empty on clusters not
+// installed by kubeadm.
+func getKubeadmClusterConfiguration(ctx context.Context, k8sClient client.Client) (kubeadmClusterConfiguration, error) {
+	var config kubeadmClusterConfiguration
+	data, err := getConfigMapData(ctx, k8sClient, KubeadmConfigName, KubeadmClusterConfigKey)
+	if err != nil {
+		return config, err
+	}
+	if err := yaml.Unmarshal([]byte(data), &config); err != nil {
+		return config, fmt.Errorf("failed to unmarshal kubeadm ClusterConfiguration: %w", err)
+	}
+	return config, nil
+}
+
+// getConfigMapData returns the value of a key of a kube-system ConfigMap, or an empty string if it does not exist.
+func getConfigMapData(ctx context.Context, k8sClient client.Client, name, key string) (string, error) {
+	configMap := &corev1.ConfigMap{}
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: name, Namespace: KubeSystemNamespace}, configMap); err != nil {
+		return "", client.IgnoreNotFound(err)
+	}
+	return configMap.Data[key], nil
+}
+
+// detectNetworkPlugin returns the comma separated names of the well-known network plugins with a DaemonSet in the cluster.
+// Only the metadata of the DaemonSets is listed, so that their pod templates are not cached.
+func detectNetworkPlugin(ctx context.Context, k8sClient client.Client) (string, error) {
+	daemonSets := &metav1.PartialObjectMetadataList{}
+	daemonSets.SetGroupVersionKind(appsv1.SchemeGroupVersion.WithKind("DaemonSetList"))
+	if err := k8sClient.List(ctx, daemonSets); err != nil {
+		return "", err
+	}
+
+	var plugins []string
+	for _, daemonSet := range daemonSets.Items {
+		if plugin, ok := networkPluginDaemonSets[daemonSet.Name]; ok {
+			plugins = append(plugins, plugin)
+		}
+	}
+	plugins = common.FilterUniqueStrings(plugins)
+	sort.Strings(plugins)
+	return strings.Join(plugins, ","), nil
+}
+
+// splitCIDRs splits a comma separated list of CIDRs, as used for dual-stack clusters.
+func splitCIDRs(cidrs string) []string {
+	var out []string
+	for _, cidr := range strings.Split(cidrs, ",") {
+		if cidr = strings.TrimSpace(cidr); cidr != "" {
+			out = append(out, cidr)
+		}
+	}
+	return out
+}
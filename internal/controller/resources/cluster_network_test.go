@@ -0,0 +1,89 @@
+package resources
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta/testrestmapper"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/dana-team/axiom-operator/api/v1alpha1"
+)
+
+var _ = Describe("ClusterNetwork", func() {
+	configMap := func(name, key, data string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: KubeSystemNamespace},
+			Data:       map[string]string{key: data},
+		}
+	}
+	node := func(name string, podCIDRs ...string) corev1.Node {
+		return corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: corev1.NodeSpec{PodCIDRs: podCIDRs}}
+	}
+
+	DescribeTable("should fall back through the network sources of a plain Kubernetes cluster",
+		func(objects []client.Object, nodes []corev1.Node, expected v1alpha1.ClusterNetwork) {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			k8sClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRESTMapper(testrestmapper.TestOnlyStaticRESTMapper(scheme)).
+				WithObjects(objects...).
+				Build()
+
+			clusterNetwork, err := GetClusterNetwork(context.Background(), log.Log, k8sClient, nodes)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(clusterNetwork).To(Equal(expected))
+		},
+		Entry("from the kubeadm ClusterConfiguration",
+			[]client.Object{
+				configMap(KubeadmConfigName, KubeadmClusterConfigKey, "networking:\n  podSubnet: 10.244.0.0/16,fd00:10:244::/56\n  serviceSubnet: 10.96.0.0/12\n"),
+				configMap(KubeProxyConfigName, KubeProxyConfigKey, "clusterCIDR: 10.32.0.0/12\n"),
+				&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "calico-node", Namespace: KubeSystemNamespace}},
+			},
+			[]corev1.Node{node("worker-1", "10.1.0.0/24")},
+			v1alpha1.ClusterNetwork{
+				NetworkType:     "Calico",
+				ClusterNetworks: []v1alpha1.ClusterNetworkEntry{{CIDR: "10.244.0.0/16"}, {CIDR: "fd00:10:244::/56"}},
+				ServiceNetworks: []string{"10.96.0.0/12"},
+				Source:          "kube-system/kubeadm-config",
+			},
+		),
+		Entry("from the kube-proxy configuration when kubeadm has no pod subnet",
+			[]client.Object{
+				configMap(KubeadmConfigName, KubeadmClusterConfigKey, "networking:\n  serviceSubnet: 10.96.0.0/12\n"),
+				configMap(KubeProxyConfigName, KubeProxyConfigKey, "clusterCIDR: 10.32.0.0/12\n"),
+				&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "cilium", Namespace: KubeSystemNamespace}},
+				&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "kube-flannel-ds", Namespace: "kube-flannel"}},
+			},
+			[]corev1.Node{node("worker-1", "10.1.0.0/24")},
+			v1alpha1.ClusterNetwork{
+				NetworkType:     "Cilium,Flannel",
+				ClusterNetworks: []v1alpha1.ClusterNetworkEntry{{CIDR: "10.32.0.0/12"}},
+				ServiceNetworks: []string{"10.96.0.0/12"},
+				Source:          "kube-system/kubeadm-config, kube-system/kube-proxy",
+			},
+		),
+		Entry("from the pod CIDRs of the nodes without kubeadm and kube-proxy",
+			nil,
+			[]corev1.Node{node("worker-2", "10.1.1.0/24"), node("worker-1", "10.1.0.0/24"), node("worker-3", "10.1.0.0/24")},
+			v1alpha1.ClusterNetwork{
+				ClusterNetworks: []v1alpha1.ClusterNetworkEntry{{CIDR: "10.1.0.0/24"}, {CIDR: "10.1.1.0/24"}},
+				Source:          "node podCIDRs",
+			},
+		),
+		Entry("with no network data",
+			[]client.Object{configMap(KubeProxyConfigName, KubeProxyConfigKey, "mode: ipvs\n")},
+			[]corev1.Node{node("worker-1")},
+			v1alpha1.ClusterNetwork{},
+		),
+	)
+})
@@ -0,0 +1,81 @@
+package resources
+
+import (
+	"context"
+	"sort"
+
+	"github.com/dana-team/axiom-operator/api/v1alpha1"
+	"github.com/go-logr/logr"
+	configv1 "github.com/openshift/api/config/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetClusterOperators lists the ClusterOperators with the status of their Available, Progressing and Degraded
+// conditions and their versions, and reports whether the platform is healthy: all the ClusterOperators are
+// available and none is degraded. Returns nil on plain Kubernetes.
+func GetClusterOperators(ctx context.Context, logger logr.Logger, k8sClient client.Client, distribution string) ([]v1alpha1.ClusterOperator, *bool, error) {
+	if !IsOpenShift(distribution) {
+		return nil, nil, nil
+	}
+
+	clusterOperatorList := &configv1.ClusterOperatorList{}
+	if err := k8sClient.List(ctx, clusterOperatorList); err != nil {
+		logger.Error(err, "Failed to list ClusterOperators")
+		return nil, nil, err
+	}
+
+	healthy := true
+	clusterOperators := make([]v1alpha1.ClusterOperator, 0, len(clusterOperatorList.Items))
+	for _, co := range clusterOperatorList.Items {
+		clusterOperator := summarizeClusterOperator(co)
+		healthy = healthy && clusterOperator.Available == string(configv1.ConditionTrue) &&
+			clusterOperator.Degraded != string(configv1.ConditionTrue)
+		clusterOperators = append(clusterOperators, clusterOperator)
+	}
+
+	sort.Slice(clusterOperators, func(i, j int) bool {
+		return clusterOperators[i].Name < clusterOperators[j].Name
+	})
+	return clusterOperators, &healthy, nil
+}
+
+// summarizeClusterOperator returns the conditions, versions and, when degraded or unavailable, the reason of a
+// ClusterOperator. Conditions missing from the ClusterOperator are reported as Unknown.
+func summarizeClusterOperator(co configv1.ClusterOperator) v1alpha1.ClusterOperator {
+	clusterOperator := v1alpha1.ClusterOperator{
+		Name:        co.Name,
+		Available:   string(configv1.ConditionUnknown),
+		Progressing: string(configv1.ConditionUnknown),
+		Degraded:    string(configv1.ConditionUnknown),
+	}
+
+	var unavailableMessage, degradedMessage string
+	for _, condition := range co.Status.Conditions {
+		switch condition.Type {
+		case configv1.OperatorAvailable:
+			clusterOperator.Available = string(condition.Status)
+			if condition.Status != configv1.ConditionTrue {
+				unavailableMessage = condition.Message
+			}
+		case configv1.OperatorProgressing:
+			clusterOperator.Progressing = string(condition.Status)
+		case configv1.OperatorDegraded:
+			clusterOperator.Degraded = string(condition.Status)
+			if condition.Status == configv1.ConditionTrue {
+				degradedMessage = condition.Message
+			}
+		}
+	}
+	clusterOperator.Message = degradedMessage
+	if clusterOperator.Message == "" {
+		clusterOperator.Message = unavailableMessage
+	}
+
+	for _, version := range co.Status.Versions {
+		clusterOperator.Versions = append(clusterOperator.Versions, v1alpha1.OperandVersion{Name: version.Name, Version: version.Version})
+	}
+	sort.Slice(clusterOperator.Versions, func(i, j int) bool {
+		return clusterOperator.Versions[i].Name < clusterOperator.Versions[j].Name
+	})
+	return clusterOperator
+}
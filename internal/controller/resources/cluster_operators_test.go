@@ -0,0 +1,30 @@
+package resources
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	configv1 "github.com/openshift/api/config/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("ClusterOperators", func() {
+	It("should report the message of a degraded operator", func() {
+		co := configv1.ClusterOperator{
+			ObjectMeta: metav1.ObjectMeta{Name: "authentication"},
+			Status: configv1.ClusterOperatorStatus{
+				Conditions: []configv1.ClusterOperatorStatusCondition{
+					{Type: configv1.OperatorAvailable, Status: configv1.ConditionTrue},
+					{Type: configv1.OperatorDegraded, Status: configv1.ConditionTrue, Message: "OAuth server is unreachable"},
+				},
+				Versions: []configv1.OperandVersion{{Name: "operator", Version: "4.16.3"}},
+			},
+		}
+
+		clusterOperator := summarizeClusterOperator(co)
+		Expect(clusterOperator.Available).To(Equal("True"))
+		Expect(clusterOperator.Progressing).To(Equal("Unknown"))
+		Expect(clusterOperator.Degraded).To(Equal("True"))
+		Expect(clusterOperator.Message).To(Equal("OAuth server is unreachable"))
+		Expect(clusterOperator.Versions).To(HaveLen(1))
+	})
+})
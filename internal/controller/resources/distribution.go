@@ -0,0 +1,30 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/dana-team/axiom-operator/api/v1alpha1"
+	"github.com/dana-team/axiom-operator/internal/controller/common"
+	"github.com/go-logr/logr"
+	configv1 "github.com/openshift/api/config/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetDistribution detects whether the cluster is an OpenShift cluster, serving the ClusterVersion API,
+// or a plain Kubernetes cluster.
+func GetDistribution(ctx context.Context, logger logr.Logger, k8sClient client.Client) (string, error) {
+	gvk := configv1.GroupVersion.WithKind("ClusterVersion")
+	if _, err := k8sClient.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+		if common.IsAPINotAvailable(err) {
+			return v1alpha1.DistributionKubernetes, nil
+		}
+		logger.Error(err, "Failed to get ClusterVersion REST mapping")
+		return "", err
+	}
+	return v1alpha1.DistributionOpenShift, nil
+}
+
+// IsOpenShift reports whether the distribution returned by GetDistribution is OpenShift.
+func IsOpenShift(distribution string) bool {
+	return distribution != v1alpha1.DistributionKubernetes
+}
@@ -0,0 +1,41 @@
+package resources
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	configv1 "github.com/openshift/api/config/v1"
+	"k8s.io/apimachinery/pkg/api/meta/testrestmapper"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/dana-team/axiom-operator/api/v1alpha1"
+)
+
+var _ = Describe("Distribution", func() {
+	distribution := func(addToScheme ...func(*runtime.Scheme) error) string {
+		scheme := runtime.NewScheme()
+		for _, add := range append(addToScheme, clientgoscheme.AddToScheme) {
+			Expect(add(scheme)).To(Succeed())
+		}
+		k8sClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithRESTMapper(testrestmapper.TestOnlyStaticRESTMapper(scheme)).
+			Build()
+
+		distribution, err := GetDistribution(context.Background(), log.Log, k8sClient)
+		Expect(err).NotTo(HaveOccurred())
+		return distribution
+	}
+
+	It("should detect OpenShift from the ClusterVersion API", func() {
+		Expect(distribution(configv1.AddToScheme)).To(Equal(v1alpha1.DistributionOpenShift))
+	})
+
+	It("should detect plain Kubernetes without the ClusterVersion API", func() {
+		Expect(distribution()).To(Equal(v1alpha1.DistributionKubernetes))
+	})
+})
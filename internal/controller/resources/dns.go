@@ -40,9 +40,10 @@ type DnsResolverConfig struct {
 }
 
 // GetClusterDnsConfiguration retrieves DNS configuration from NodeNetworkConfigurationPolicy
-// and converts it to ClusterDnsConfig format
-func GetClusterDnsConfiguration(ctx context.Context, logger logr.Logger, k8sClient client.Client, ci *v1alpha1.ClusterInfo) (v1alpha1.ClusterDnsConfig, error) {
-	if ci.Spec.HostedCluster {
+// and converts it to ClusterDnsConfig format. Hosted clusters and clusters without nmstate read
+// the resolv.conf of a node instead.
+func GetClusterDnsConfiguration(ctx context.Context, logger logr.Logger, k8sClient client.Client, distribution string, hosted bool) (v1alpha1.ClusterDnsConfig, error) {
+	if hosted || !IsOpenShift(distribution) {
 		dnsConfig, err := getDNSFromResolveConf(ctx, k8sClient, logger)
 		if err != nil {
 			return v1alpha1.ClusterDnsConfig{}, err
@@ -71,6 +72,9 @@ func getDNSFromNNCP(ctx context.Context, logger logr.Logger, k8sClient client.Cl
 	if err := yamlv3.Unmarshal(nncp.Spec.DesiredState.Raw, &state); err != nil {
 		logger.Error(err, "failed to unmarshal DesiredState")
 	}
+	if state.DNSResolver == nil {
+		return v1alpha1.ClusterDnsConfig{}, nil
+	}
 
 	return v1alpha1.ClusterDnsConfig{
 			SearchDomains: state.DNSResolver.Config.Search,
@@ -0,0 +1,75 @@
+package resources
+
+import (
+	"context"
+	"net/url"
+	"sort"
+
+	"github.com/dana-team/axiom-operator/api/v1alpha1"
+	"github.com/dana-team/axiom-operator/internal/controller/common"
+	"github.com/dana-team/axiom-operator/internal/netbox"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetSegmentDrift compares the segments used by the cluster nodes with the prefixes assigned to the cluster in NetBox.
+// Hosted clusters take their segments from NetBox, so the used segments are discovered from NodeNetworkState.
+// Other clusters take their segments from NodeNetworkState, so the registered prefixes are fetched from NetBox.
+// Returns netbox.ErrNotConfigured when the NetBox client is nil.
+func GetSegmentDrift(ctx context.Context, logger logr.Logger, k8sClient client.Client, ci *v1alpha1.ClusterInfo, hosted bool, nb *netbox.Client, nodes []corev1.Node, segments []v1alpha1.Segment, clusterName string) (v1alpha1.SegmentDrift, error) {
+	if nb == nil {
+		return v1alpha1.SegmentDrift{}, netbox.ErrNotConfigured
+	}
+
+	var err error
+	var used, registered []v1alpha1.Segment
+	if hosted {
+		registered = segments
+		if used, err = getSegmentsFromNodeNetworkState(ctx, logger, k8sClient, nodes, includeLinkLocalSegments(ci)); err != nil {
+			return v1alpha1.SegmentDrift{}, err
+		}
+	} else {
+		used = segments
+		results, err := netbox.List[NetBoxResult](ctx, nb, ApiPrefix, url.Values{"cf_Cluster": {clusterName}})
+		if err != nil {
+			logger.Error(err, "Failed to get segments from NetBox")
+			return v1alpha1.SegmentDrift{}, err
+		}
+		for _, prefix := range results {
+			registered = append(registered, v1alpha1.Segment{Prefix: prefix.Prefix, Family: common.SegmentIPFamily(prefix.Prefix)})
+		}
+	}
+
+	return CompareSegments(used, registered), nil
+}
+
+// CompareSegments returns the used segments missing from the registered ones and the registered
+// segments that are not used. Segments are compared by their exact prefix.
+func CompareSegments(used, registered []v1alpha1.Segment) v1alpha1.SegmentDrift {
+	usedPrefixes := segmentPrefixes(used)
+	registeredPrefixes := segmentPrefixes(registered)
+
+	drift := v1alpha1.SegmentDrift{}
+	for prefix := range usedPrefixes {
+		if _, ok := registeredPrefixes[prefix]; !ok {
+			drift.Unregistered = append(drift.Unregistered, prefix)
+		}
+	}
+	for prefix := range registeredPrefixes {
+		if _, ok := usedPrefixes[prefix]; !ok {
+			drift.Unused = append(drift.Unused, prefix)
+		}
+	}
+	sort.Strings(drift.Unregistered)
+	sort.Strings(drift.Unused)
+	return drift
+}
+
+func segmentPrefixes(segments []v1alpha1.Segment) map[string]struct{} {
+	prefixes := make(map[string]struct{}, len(segments))
+	for _, segment := range segments {
+		prefixes[segment.Prefix] = struct{}{}
+	}
+	return prefixes
+}
@@ -0,0 +1,33 @@
+package resources
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/dana-team/axiom-operator/api/v1alpha1"
+)
+
+var _ = Describe("Segment drift", func() {
+	segments := func(prefixes ...string) []v1alpha1.Segment {
+		out := []v1alpha1.Segment{}
+		for _, prefix := range prefixes {
+			out = append(out, v1alpha1.Segment{Prefix: prefix})
+		}
+		return out
+	}
+
+	It("should report unregistered and unused prefixes", func() {
+		drift := CompareSegments(
+			segments("10.0.2.0/24", "10.0.1.0/24", "10.0.3.0/24"),
+			segments("10.0.1.0/24", "10.0.9.0/24"),
+		)
+		Expect(drift.Unregistered).To(Equal([]string{"10.0.2.0/24", "10.0.3.0/24"}))
+		Expect(drift.Unused).To(Equal([]string{"10.0.9.0/24"}))
+	})
+
+	It("should report no drift when the prefixes match", func() {
+		drift := CompareSegments(segments("10.0.1.0/24"), segments("10.0.1.0/24"))
+		Expect(drift.Unregistered).To(BeEmpty())
+		Expect(drift.Unused).To(BeEmpty())
+	})
+})
@@ -0,0 +1,26 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/dana-team/axiom-operator/internal/controller/common"
+	"github.com/go-logr/logr"
+	configv1 "github.com/openshift/api/config/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetInfrastructure retrieves the cluster Infrastructure configuration.
+// Returns nil without an error when the cluster does not serve it, e.g. on vanilla Kubernetes.
+func GetInfrastructure(ctx context.Context, logger logr.Logger, k8sClient client.Client) (*configv1.Infrastructure, error) {
+	infrastructure := &configv1.Infrastructure{}
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: "cluster"}, infrastructure); err != nil {
+		if common.IsAPINotAvailable(err) || apierrors.IsNotFound(err) {
+			logger.Info("Cluster Infrastructure is not available")
+			return nil, nil
+		}
+		logger.Error(err, "Failed to get cluster Infrastructure")
+		return nil, err
+	}
+	return infrastructure, nil
+}
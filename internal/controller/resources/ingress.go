@@ -0,0 +1,91 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/dana-team/axiom-operator/api/v1alpha1"
+	"github.com/dana-team/axiom-operator/internal/controller/common"
+	"github.com/go-logr/logr"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	IngressOperatorNamespace     = "openshift-ingress-operator"
+	IngressNamespace             = "openshift-ingress"
+	DefaultIngressControllerName = "default"
+	routerServicePrefix          = "router-"
+)
+
+// GetIngressControllers retrieves the OpenShift IngressControllers with their domain, endpoint publishing strategy,
+// replicas, default certificate and the addresses of their load balancer service.
+// Returns nil without an error when the cluster does not serve IngressControllers.
+func GetIngressControllers(ctx context.Context, logger logr.Logger, k8sClient client.Client) ([]v1alpha1.IngressController, error) {
+	ingressControllerList := &operatorv1.IngressControllerList{}
+	if err := k8sClient.List(ctx, ingressControllerList, client.InNamespace(IngressOperatorNamespace)); err != nil {
+		if common.IsAPINotAvailable(err) {
+			logger.Info("IngressControllers are not available")
+			return nil, nil
+		}
+		logger.Error(err, "Failed to list IngressControllers")
+		return nil, err
+	}
+
+	ingressControllers := make([]v1alpha1.IngressController, 0, len(ingressControllerList.Items))
+	for _, ic := range ingressControllerList.Items {
+		ingressController := v1alpha1.IngressController{
+			Name:              ic.Name,
+			Domain:            ic.Status.Domain,
+			AvailableReplicas: ic.Status.AvailableReplicas,
+		}
+		if ic.Spec.Replicas != nil {
+			ingressController.Replicas = *ic.Spec.Replicas
+		}
+		if ic.Spec.DefaultCertificate != nil {
+			ingressController.DefaultCertificate = ic.Spec.DefaultCertificate.Name
+		}
+		if ic.Status.EndpointPublishingStrategy != nil {
+			ingressController.EndpointPublishingStrategy = string(ic.Status.EndpointPublishingStrategy.Type)
+		}
+
+		if ingressController.EndpointPublishingStrategy == string(operatorv1.LoadBalancerServiceStrategyType) {
+			addresses, err := getRouterLoadBalancerAddresses(ctx, k8sClient, ic.Name)
+			if err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to get load balancer service of IngressController %s", ic.Name))
+				return nil, err
+			}
+			ingressController.LoadBalancerAddresses = addresses
+		}
+		ingressControllers = append(ingressControllers, ingressController)
+	}
+
+	sort.Slice(ingressControllers, func(i, j int) bool {
+		return ingressControllers[i].Name < ingressControllers[j].Name
+	})
+	return ingressControllers, nil
+}
+
+// getRouterLoadBalancerAddresses returns the IPs and hostnames of the load balancer service of an IngressController.
+func getRouterLoadBalancerAddresses(ctx context.Context, k8sClient client.Client, ingressControllerName string) ([]string, error) {
+	service := &corev1.Service{}
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: routerServicePrefix + ingressControllerName, Namespace: IngressNamespace}, service); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var addresses []string
+	for _, ingress := range service.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			addresses = append(addresses, ingress.IP)
+		} else if ingress.Hostname != "" {
+			addresses = append(addresses, ingress.Hostname)
+		}
+	}
+	return addresses, nil
+}
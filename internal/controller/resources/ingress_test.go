@@ -0,0 +1,87 @@
+package resources
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta/testrestmapper"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/dana-team/axiom-operator/api/v1alpha1"
+)
+
+var _ = Describe("IngressControllers", func() {
+	ingressController := func(name, domain string, strategy operatorv1.EndpointPublishingStrategyType) *operatorv1.IngressController {
+		return &operatorv1.IngressController{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: IngressOperatorNamespace},
+			Spec:       operatorv1.IngressControllerSpec{Replicas: ptr.To[int32](2)},
+			Status: operatorv1.IngressControllerStatus{
+				Domain:                     domain,
+				AvailableReplicas:          1,
+				EndpointPublishingStrategy: &operatorv1.EndpointPublishingStrategy{Type: strategy},
+			},
+		}
+	}
+
+	It("should read the domain, endpoint publishing strategy, replicas and default certificate", func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(operatorv1.AddToScheme(scheme)).To(Succeed())
+		defaultIngressController := ingressController(DefaultIngressControllerName, "apps.ocp.example.com", operatorv1.LoadBalancerServiceStrategyType)
+		defaultIngressController.Spec.DefaultCertificate = &corev1.LocalObjectReference{Name: "wildcard-apps"}
+		routerService := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: routerServicePrefix + DefaultIngressControllerName, Namespace: IngressNamespace},
+			Status: corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{Ingress: []corev1.LoadBalancerIngress{
+				{IP: "192.0.2.10"},
+				{Hostname: "router.lb.example.com"},
+			}}},
+		}
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			ingressController("sharded", "shard.ocp.example.com", operatorv1.HostNetworkStrategyType),
+			defaultIngressController,
+			routerService,
+		).Build()
+
+		ingressControllers, err := GetIngressControllers(context.Background(), log.Log, k8sClient)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ingressControllers).To(Equal([]v1alpha1.IngressController{
+			{
+				Name:                       DefaultIngressControllerName,
+				Domain:                     "apps.ocp.example.com",
+				EndpointPublishingStrategy: "LoadBalancerService",
+				Replicas:                   2,
+				AvailableReplicas:          1,
+				DefaultCertificate:         "wildcard-apps",
+				LoadBalancerAddresses:      []string{"192.0.2.10", "router.lb.example.com"},
+			},
+			{
+				Name:                       "sharded",
+				Domain:                     "shard.ocp.example.com",
+				EndpointPublishingStrategy: "HostNetwork",
+				Replicas:                   2,
+				AvailableReplicas:          1,
+			},
+		}))
+	})
+
+	It("should not fail without the IngressController API", func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		k8sClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithRESTMapper(testrestmapper.TestOnlyStaticRESTMapper(scheme)).
+			Build()
+
+		ingressControllers, err := GetIngressControllers(context.Background(), log.Log, k8sClient)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ingressControllers).To(BeNil())
+	})
+})
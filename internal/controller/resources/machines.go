@@ -0,0 +1,131 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/dana-team/axiom-operator/api/v1alpha1"
+	"github.com/dana-team/axiom-operator/internal/controller/common"
+	"github.com/go-logr/logr"
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	mcfgv1 "github.com/openshift/api/machineconfiguration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Labels set on Machines by the machine controllers.
+const (
+	MachineInstanceTypeLabel = "machine.openshift.io/instance-type"
+	MachineRegionLabel       = "machine.openshift.io/region"
+	MachineZoneLabel         = "machine.openshift.io/zone"
+)
+
+// GetMachineConfigPools retrieves the machine counts, rendered configuration and update state of the
+// MachineConfigPools. Returns nil on plain Kubernetes and on clusters without the MachineConfigPool API, e.g.
+// hosted clusters.
+func GetMachineConfigPools(ctx context.Context, logger logr.Logger, k8sClient client.Client, distribution string) ([]v1alpha1.MachineConfigPool, error) {
+	if !IsOpenShift(distribution) {
+		return nil, nil
+	}
+
+	poolList := &mcfgv1.MachineConfigPoolList{}
+	if err := k8sClient.List(ctx, poolList); err != nil {
+		if common.IsAPINotAvailable(err) {
+			logger.Info("MachineConfigPools are not available, skipping")
+			return nil, nil
+		}
+		logger.Error(err, "Failed to list MachineConfigPools")
+		return nil, err
+	}
+
+	pools := make([]v1alpha1.MachineConfigPool, 0, len(poolList.Items))
+	for _, pool := range poolList.Items {
+		pools = append(pools, machineConfigPool(pool))
+	}
+	sort.Slice(pools, func(i, j int) bool {
+		return pools[i].Name < pools[j].Name
+	})
+	return pools, nil
+}
+
+// machineConfigPool summarizes a MachineConfigPool.
+func machineConfigPool(pool mcfgv1.MachineConfigPool) v1alpha1.MachineConfigPool {
+	summary := v1alpha1.MachineConfigPool{
+		Name:                    pool.Name,
+		MachineCount:            pool.Status.MachineCount,
+		ReadyMachineCount:       pool.Status.ReadyMachineCount,
+		UpdatedMachineCount:     pool.Status.UpdatedMachineCount,
+		UnavailableMachineCount: pool.Status.UnavailableMachineCount,
+		DegradedMachineCount:    pool.Status.DegradedMachineCount,
+		RenderedConfig:          pool.Status.Configuration.Name,
+		Paused:                  pool.Spec.Paused,
+	}
+	for _, condition := range pool.Status.Conditions {
+		switch condition.Type {
+		case mcfgv1.MachineConfigPoolUpdating:
+			summary.Updating = condition.Status == corev1.ConditionTrue
+		case mcfgv1.MachineConfigPoolDegraded:
+			summary.Degraded = condition.Status == corev1.ConditionTrue
+		}
+	}
+	return summary
+}
+
+// GetMachineSets retrieves the replicas of the MachineSets, with the instance type, region and zone of their
+// Machines. Returns nil on plain Kubernetes and on clusters without the Machine API, e.g. hosted clusters.
+func GetMachineSets(ctx context.Context, logger logr.Logger, k8sClient client.Client, distribution string) ([]v1alpha1.MachineSet, error) {
+	if !IsOpenShift(distribution) {
+		return nil, nil
+	}
+
+	machineSetList := &machinev1beta1.MachineSetList{}
+	if err := k8sClient.List(ctx, machineSetList); err != nil {
+		if common.IsAPINotAvailable(err) {
+			logger.Info("MachineSets are not available, skipping")
+			return nil, nil
+		}
+		logger.Error(err, "Failed to list MachineSets")
+		return nil, err
+	}
+	machineList := &machinev1beta1.MachineList{}
+	if err := k8sClient.List(ctx, machineList); err != nil {
+		logger.Error(err, "Failed to list Machines")
+		return nil, err
+	}
+
+	machineSets := make([]v1alpha1.MachineSet, 0, len(machineSetList.Items))
+	for _, ms := range machineSetList.Items {
+		machineSet := v1alpha1.MachineSet{
+			Name:              ms.Name,
+			Replicas:          ptr.Deref(ms.Spec.Replicas, 1),
+			ReadyReplicas:     ms.Status.ReadyReplicas,
+			AvailableReplicas: ms.Status.AvailableReplicas,
+		}
+		selector, err := metav1.LabelSelectorAsSelector(&ms.Spec.Selector)
+		if err != nil {
+			logger.Info(fmt.Sprintf("Ignoring the invalid selector of MachineSet %s: %v", ms.Name, err))
+			machineSets = append(machineSets, machineSet)
+			continue
+		}
+		for _, machine := range machineList.Items {
+			if machine.Namespace != ms.Namespace || !selector.Matches(labels.Set(machine.Labels)) {
+				continue
+			}
+			machineSet.InstanceType = machine.Labels[MachineInstanceTypeLabel]
+			machineSet.Region = machine.Labels[MachineRegionLabel]
+			machineSet.Zone = machine.Labels[MachineZoneLabel]
+			if machineSet.InstanceType != "" {
+				break
+			}
+		}
+		machineSets = append(machineSets, machineSet)
+	}
+	sort.Slice(machineSets, func(i, j int) bool {
+		return machineSets[i].Name < machineSets[j].Name
+	})
+	return machineSets, nil
+}
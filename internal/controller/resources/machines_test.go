@@ -0,0 +1,34 @@
+package resources
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	mcfgv1 "github.com/openshift/api/machineconfiguration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("MachineConfigPools", func() {
+	It("should report a pool stuck updating", func() {
+		pool := mcfgv1.MachineConfigPool{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+			Status: mcfgv1.MachineConfigPoolStatus{
+				Configuration:           mcfgv1.MachineConfigPoolStatusConfiguration{ObjectReference: corev1.ObjectReference{Name: "rendered-worker-1a2b"}},
+				MachineCount:            3,
+				UpdatedMachineCount:     1,
+				DegradedMachineCount:    1,
+				UnavailableMachineCount: 1,
+				Conditions: []mcfgv1.MachineConfigPoolCondition{
+					{Type: mcfgv1.MachineConfigPoolUpdating, Status: corev1.ConditionTrue},
+					{Type: mcfgv1.MachineConfigPoolDegraded, Status: corev1.ConditionTrue},
+				},
+			},
+		}
+
+		summary := machineConfigPool(pool)
+		Expect(summary.RenderedConfig).To(Equal("rendered-worker-1a2b"))
+		Expect(summary.UpdatedMachineCount).To(Equal(int32(1)))
+		Expect(summary.Updating).To(BeTrue())
+		Expect(summary.Degraded).To(BeTrue())
+	})
+})
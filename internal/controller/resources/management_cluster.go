@@ -0,0 +1,113 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/dana-team/axiom-operator/api/v1alpha1"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const ManagementKubeconfigSecretKey = "kubeconfig"
+
+// HostedClusterGVK is the kind of the HyperShift HostedCluster, read as an unstructured object.
+var HostedClusterGVK = schema.GroupVersionKind{Group: "hypershift.openshift.io", Version: "v1beta1", Kind: "HostedCluster"}
+
+// ErrManagementClusterNotConfigured is returned when a hosted cluster has no spec.managementCluster.
+var ErrManagementClusterNotConfigured = errors.New("management cluster is not configured")
+
+// ManagementClientCache keeps the management cluster clients across reconciles, so that their discovery information
+// and connections are reused. A client is rebuilt only when its kubeconfig Secret changes. The zero value is ready
+// to use.
+type ManagementClientCache struct {
+	mu      sync.Mutex
+	clients map[string]cachedManagementClient
+}
+
+// cachedManagementClient is a management cluster client with the resourceVersion of the Secret it was built from.
+type cachedManagementClient struct {
+	version string
+	client  client.Client
+}
+
+// get returns the client cached for the Secret, or builds and caches a new one when there is none or the Secret
+// changed. A nil cache always builds a new client.
+func (c *ManagementClientCache) get(secret, version string, build func() (client.Client, error)) (client.Client, error) {
+	if c == nil {
+		return build()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cached, ok := c.clients[secret]; ok && cached.version == version {
+		return cached.client, nil
+	}
+	managementClient, err := build()
+	if err != nil {
+		delete(c.clients, secret)
+		return nil, err
+	}
+	if c.clients == nil {
+		c.clients = map[string]cachedManagementClient{}
+	}
+	c.clients[secret] = cachedManagementClient{version: version, client: managementClient}
+	return managementClient, nil
+}
+
+// GetHostedCluster retrieves the HostedCluster of a hosted cluster from its management cluster, using the
+// kubeconfig of the Secret referenced by spec.managementCluster. The Secret is read through apiReader on every call,
+// so a rotated kubeconfig is used on the next reconcile without caching Secrets. The management cluster client is
+// taken from clients and rebuilt only when the resourceVersion of the Secret changes.
+// Returns ErrManagementClusterNotConfigured when spec.managementCluster is not set.
+func GetHostedCluster(ctx context.Context, logger logr.Logger, apiReader client.Reader, clients *ManagementClientCache, ci *v1alpha1.ClusterInfo) (*unstructured.Unstructured, error) {
+	ref := ManagementKubeconfigSecretRef(ci)
+	if ref == nil {
+		return nil, ErrManagementClusterNotConfigured
+	}
+
+	secret := &corev1.Secret{}
+	if err := apiReader.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: ref.Namespace}, secret); err != nil {
+		logger.Error(err, fmt.Sprintf("Failed to get management cluster kubeconfig Secret %s/%s", ref.Namespace, ref.Name))
+		return nil, err
+	}
+	managementClient, err := clients.get(ref.Namespace+"/"+ref.Name, secret.ResourceVersion, func() (client.Client, error) {
+		restConfig, err := clientcmd.RESTConfigFromKubeConfig(secret.Data[ManagementKubeconfigSecretKey])
+		if err != nil {
+			return nil, fmt.Errorf("failed to load management cluster kubeconfig from Secret %s/%s: %w", ref.Namespace, ref.Name, err)
+		}
+		managementClient, err := client.New(restConfig, client.Options{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create management cluster client: %w", err)
+		}
+		return managementClient, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	spec := ci.Spec.ManagementCluster
+	hostedCluster := &unstructured.Unstructured{}
+	hostedCluster.SetGroupVersionKind(HostedClusterGVK)
+	key := client.ObjectKey{Name: spec.HostedClusterName, Namespace: spec.HostedClusterNamespace}
+	if err := managementClient.Get(ctx, key, hostedCluster); err != nil {
+		logger.Error(err, fmt.Sprintf("Failed to get HostedCluster %s", key))
+		return nil, err
+	}
+	return hostedCluster, nil
+}
+
+// ManagementKubeconfigSecretRef returns the management cluster kubeconfig Secret referenced by the ClusterInfo with
+// its namespace defaulted to the operator namespace, or nil when no management cluster is configured.
+func ManagementKubeconfigSecretRef(ci *v1alpha1.ClusterInfo) *corev1.SecretReference {
+	if ci.Spec.ManagementCluster == nil {
+		return nil
+	}
+	return withDefaultNamespace(&ci.Spec.ManagementCluster.KubeconfigSecretRef)
+}
@@ -0,0 +1,30 @@
+package resources
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("ManagementClientCache", func() {
+	It("should reuse the client until the Secret changes", func() {
+		builds := 0
+		build := func() (client.Client, error) {
+			builds++
+			return fake.NewClientBuilder().Build(), nil
+		}
+		clients := &ManagementClientCache{}
+
+		first, err := clients.get("axiom/management-kubeconfig", "1", build)
+		Expect(err).NotTo(HaveOccurred())
+		second, err := clients.get("axiom/management-kubeconfig", "1", build)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(second).To(BeIdenticalTo(first))
+
+		rotated, err := clients.get("axiom/management-kubeconfig", "2", build)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rotated).NotTo(BeIdenticalTo(first))
+		Expect(builds).To(Equal(2))
+	})
+})
@@ -0,0 +1,62 @@
+package resources
+
+import (
+	"strings"
+
+	"github.com/dana-team/axiom-operator/api/v1alpha1"
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	MasterNodeRoleLabel       = "node-role.kubernetes.io/master"
+	ControlPlaneNodeRoleLabel = "node-role.kubernetes.io/control-plane"
+	// HyperShiftPrefix prefixes the labels and annotations set by HyperShift on the nodes of hosted clusters.
+	HyperShiftPrefix = "hypershift.openshift.io/"
+)
+
+// DetectClusterMode detects whether the cluster has a hosted control plane. A cluster is Hosted when its
+// Infrastructure reports an External control plane topology, when its nodes carry HyperShift labels or annotations,
+// or, on OpenShift, when none of its nodes is a control plane node. Managed Kubernetes clusters, e.g. EKS, GKE or AKS,
+// hide their control plane nodes as well, so they are Standalone unless they run HyperShift nodes.
+// Returns the mode and the reason it was detected.
+func DetectClusterMode(distribution string, infrastructure *configv1.Infrastructure, nodes []corev1.Node) (string, string) {
+	if infrastructure != nil && infrastructure.Status.ControlPlaneTopology == configv1.ExternalTopologyMode {
+		return v1alpha1.ClusterModeHosted, "the control plane topology is External"
+	}
+
+	hasControlPlaneNode := false
+	for _, node := range nodes {
+		if hasKeyWithPrefix(node.Labels, HyperShiftPrefix) || hasKeyWithPrefix(node.Annotations, HyperShiftPrefix) {
+			return v1alpha1.ClusterModeHosted, "node " + node.Name + " is managed by HyperShift"
+		}
+		_, master := node.Labels[MasterNodeRoleLabel]
+		_, controlPlane := node.Labels[ControlPlaneNodeRoleLabel]
+		hasControlPlaneNode = hasControlPlaneNode || master || controlPlane
+	}
+	if !IsOpenShift(distribution) {
+		return v1alpha1.ClusterModeStandalone, "no node is managed by HyperShift"
+	}
+	if len(nodes) > 0 && !hasControlPlaneNode {
+		return v1alpha1.ClusterModeHosted, "no control plane node was found"
+	}
+	return v1alpha1.ClusterModeStandalone, "control plane nodes were found"
+}
+
+// IsHostedCluster reports whether the cluster is handled as a hosted cluster: spec.hostedCluster when set,
+// otherwise whether the mode returned by DetectClusterMode is Hosted.
+func IsHostedCluster(ci *v1alpha1.ClusterInfo, detectedMode string) bool {
+	if ci.Spec.HostedCluster != nil {
+		return *ci.Spec.HostedCluster
+	}
+	return detectedMode == v1alpha1.ClusterModeHosted
+}
+
+func hasKeyWithPrefix(m map[string]string, prefix string) bool {
+	for key := range m {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
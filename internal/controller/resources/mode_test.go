@@ -0,0 +1,52 @@
+package resources
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/dana-team/axiom-operator/api/v1alpha1"
+)
+
+var _ = Describe("Cluster mode", func() {
+	node := func(labels map[string]string) corev1.Node {
+		return corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node", Labels: labels}}
+	}
+
+	It("should detect hosted and standalone clusters", func() {
+		external := &configv1.Infrastructure{Status: configv1.InfrastructureStatus{ControlPlaneTopology: configv1.ExternalTopologyMode}}
+		master := node(map[string]string{MasterNodeRoleLabel: ""})
+		worker := node(map[string]string{"node-role.kubernetes.io/worker": ""})
+
+		mode := func(infrastructure *configv1.Infrastructure, nodes ...corev1.Node) string {
+			mode, _ := DetectClusterMode(v1alpha1.DistributionOpenShift, infrastructure, nodes)
+			return mode
+		}
+
+		Expect(mode(external, master)).To(Equal(v1alpha1.ClusterModeHosted))
+		Expect(mode(nil, worker)).To(Equal(v1alpha1.ClusterModeHosted))
+		Expect(mode(nil, master, worker)).To(Equal(v1alpha1.ClusterModeStandalone))
+	})
+
+	It("should not detect managed Kubernetes clusters without control plane nodes as hosted", func() {
+		worker := node(map[string]string{"eks.amazonaws.com/nodegroup": "workers"})
+		hyperShiftWorker := node(map[string]string{HyperShiftPrefix + "nodePool": "workers"})
+
+		mode, _ := DetectClusterMode(v1alpha1.DistributionKubernetes, nil, []corev1.Node{worker})
+		Expect(mode).To(Equal(v1alpha1.ClusterModeStandalone))
+		mode, _ = DetectClusterMode(v1alpha1.DistributionKubernetes, nil, []corev1.Node{worker, hyperShiftWorker})
+		Expect(mode).To(Equal(v1alpha1.ClusterModeHosted))
+	})
+
+	It("should let spec.hostedCluster override the detected mode", func() {
+		ci := &v1alpha1.ClusterInfo{}
+		Expect(IsHostedCluster(ci, v1alpha1.ClusterModeHosted)).To(BeTrue())
+		Expect(IsHostedCluster(ci, v1alpha1.ClusterModeStandalone)).To(BeFalse())
+
+		ci.Spec.HostedCluster = ptr.To(false)
+		Expect(IsHostedCluster(ci, v1alpha1.ClusterModeHosted)).To(BeFalse())
+	})
+})
@@ -0,0 +1,122 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/dana-team/axiom-operator/api/v1alpha1"
+	"github.com/dana-team/axiom-operator/internal/netbox"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	NetBoxSecretURLKey                = "url"
+	NetBoxSecretTokenKey              = "token"
+	NetBoxSecretCAKey                 = "ca.crt"
+	NetBoxSecretInsecureSkipVerifyKey = "insecureSkipVerify"
+)
+
+// NetBoxClientCache keeps the NetBox clients across reconciles, so that the rate limit of a client applies to all
+// the requests sent to its NetBox. A client is rebuilt only when its configuration changes. The zero value is ready
+// to use.
+type NetBoxClientCache struct {
+	mu      sync.Mutex
+	clients map[string]cachedNetBoxClient
+}
+
+// cachedNetBoxClient is a NetBox client with the version of the configuration it was built from.
+type cachedNetBoxClient struct {
+	version string
+	client  *netbox.Client
+}
+
+// get returns the client cached for the configuration source, or builds and caches a new one when there is none or
+// its configuration version changed. A nil cache always builds a new client.
+func (c *NetBoxClientCache) get(source, version string, build func() (*netbox.Client, error)) (*netbox.Client, error) {
+	if c == nil {
+		return build()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cached, ok := c.clients[source]; ok && cached.version == version {
+		return cached.client, nil
+	}
+	nb, err := build()
+	if err != nil {
+		delete(c.clients, source)
+		return nil, err
+	}
+	if c.clients == nil {
+		c.clients = map[string]cachedNetBoxClient{}
+	}
+	c.clients[source] = cachedNetBoxClient{version: version, client: nb}
+	return nb, nil
+}
+
+// GetNetBoxClient returns a NetBox client configured from the Secret referenced by spec.netbox.secretRef, or from
+// the NETBOX_* environment variables of the operator when no Secret is referenced. The client is taken from clients
+// and rebuilt only when the resourceVersion of the Secret changes. The Secret is read through apiReader on every call,
+// so rotated credentials are used on the next reconcile without caching Secrets. Returns the client together with a
+// description of the configuration source, or netbox.ErrNotConfigured when no URL and token are set.
+func GetNetBoxClient(ctx context.Context, logger logr.Logger, apiReader client.Reader, clients *NetBoxClientCache, ci *v1alpha1.ClusterInfo) (*netbox.Client, string, error) {
+	ref := NetBoxSecretRef(ci)
+	if ref == nil {
+		source := "the operator environment"
+		nb, err := clients.get(source, "", func() (*netbox.Client, error) {
+			cfg, err := netbox.ConfigFromEnv()
+			if err != nil {
+				return nil, err
+			}
+			return netbox.NewClient(cfg)
+		})
+		return nb, source, err
+	}
+
+	source := fmt.Sprintf("Secret %s/%s", ref.Namespace, ref.Name)
+	secret := &corev1.Secret{}
+	if err := apiReader.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: ref.Namespace}, secret); err != nil {
+		logger.Error(err, fmt.Sprintf("Failed to get NetBox %s", source))
+		return nil, source, err
+	}
+
+	nb, err := clients.get(source, secret.ResourceVersion, func() (*netbox.Client, error) {
+		cfg := netbox.Config{
+			URL:      string(secret.Data[NetBoxSecretURLKey]),
+			Token:    string(secret.Data[NetBoxSecretTokenKey]),
+			CABundle: secret.Data[NetBoxSecretCAKey],
+		}
+		if insecure, ok := secret.Data[NetBoxSecretInsecureSkipVerifyKey]; ok {
+			skip, err := strconv.ParseBool(string(insecure))
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s of %s: %w", NetBoxSecretInsecureSkipVerifyKey, source, err)
+			}
+			cfg.InsecureSkipVerify = skip
+		}
+		return netbox.NewClient(cfg)
+	})
+	return nb, source, err
+}
+
+// NetBoxSecretRef returns the NetBox Secret referenced by the ClusterInfo with its namespace defaulted
+// to the operator namespace, or nil when no Secret is referenced.
+func NetBoxSecretRef(ci *v1alpha1.ClusterInfo) *corev1.SecretReference {
+	if ci.Spec.NetBox == nil || ci.Spec.NetBox.SecretRef == nil {
+		return nil
+	}
+	return withDefaultNamespace(ci.Spec.NetBox.SecretRef)
+}
+
+// withDefaultNamespace returns a copy of a Secret reference with its namespace defaulted to the operator namespace.
+func withDefaultNamespace(ref *corev1.SecretReference) *corev1.SecretReference {
+	ref = ref.DeepCopy()
+	if ref.Namespace == "" {
+		ref.Namespace = os.Getenv("POD_NAMESPACE")
+	}
+	return ref
+}
@@ -0,0 +1,47 @@
+package resources
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/dana-team/axiom-operator/api/v1alpha1"
+)
+
+var _ = Describe("NetBoxClientCache", func() {
+	It("should reuse the client until the Secret changes", func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "netbox", Namespace: "axiom"},
+			Data: map[string][]byte{
+				NetBoxSecretURLKey:   []byte("https://netbox.example.com"),
+				NetBoxSecretTokenKey: []byte("token"),
+			},
+		}
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+		ci := &v1alpha1.ClusterInfo{Spec: v1alpha1.ClusterInfoSpec{NetBox: &v1alpha1.NetBoxSpec{
+			SecretRef: &corev1.SecretReference{Name: "netbox", Namespace: "axiom"},
+		}}}
+		clients := &NetBoxClientCache{}
+
+		first, _, err := GetNetBoxClient(context.Background(), log.Log, k8sClient, clients, ci)
+		Expect(err).NotTo(HaveOccurred())
+		second, _, err := GetNetBoxClient(context.Background(), log.Log, k8sClient, clients, ci)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(second).To(BeIdenticalTo(first))
+
+		secret.Data[NetBoxSecretTokenKey] = []byte("rotated")
+		Expect(k8sClient.Update(context.Background(), secret)).To(Succeed())
+		rotated, _, err := GetNetBoxClient(context.Background(), log.Log, k8sClient, clients, ci)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rotated).NotTo(BeIdenticalTo(first))
+	})
+})
@@ -0,0 +1,402 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/dana-team/axiom-operator/api/v1alpha1"
+	"github.com/dana-team/axiom-operator/internal/netbox"
+	"github.com/go-logr/logr"
+)
+
+const (
+	NetBoxClustersPath         = "/api/virtualization/clusters/"
+	NetBoxVirtualMachinesPath  = "/api/virtualization/virtual-machines/"
+	NetBoxVMInterfacesPath     = "/api/virtualization/interfaces/"
+	NetBoxDevicesPath          = "/api/dcim/devices/"
+	NetBoxDeviceInterfacesPath = "/api/dcim/interfaces/"
+	NetBoxIPAddressesPath      = "/api/ipam/ip-addresses/"
+	NetBoxVRFsPath             = "/api/ipam/vrfs/"
+
+	// DefaultNetBoxClusterType is the slug of the NetBox cluster type used when the write-back spec sets none.
+	DefaultNetBoxClusterType = "openshift"
+
+	netBoxVMInterfaceType     = "virtualization.vminterface"
+	netBoxDeviceInterfaceType = "dcim.interface"
+	// defaultNetBoxInterface names the interface created on virtual machines when the
+	// interface holding the node address cannot be determined.
+	defaultNetBoxInterface = "default"
+)
+
+// netBoxWriter applies the changes made to NetBox, or only records them in dry-run mode.
+type netBoxWriter struct {
+	nb      *netbox.Client
+	logger  logr.Logger
+	dryRun  bool
+	changes []string
+}
+
+// write sends a create or update request unless running in dry-run mode. The change is recorded in both cases.
+func (w *netBoxWriter) write(ctx context.Context, method, path string, body, out any, change string) error {
+	w.changes = append(w.changes, change)
+	if w.dryRun {
+		w.logger.Info(fmt.Sprintf("NetBox dry-run: would %s", change))
+		return nil
+	}
+	w.logger.Info(fmt.Sprintf("NetBox: %s", change))
+	return w.nb.Do(ctx, method, path, nil, body, out)
+}
+
+// SyncClusterToNetBox registers the cluster described by the status in NetBox. It creates the NetBox cluster
+// when missing, attaches the node devices, or virtual machines when no device matches a node name, to it with
+// their internal IPs and sets the Cluster custom field on the NetBox prefixes of the cluster segments.
+// Only the differences are written, so repeated syncs are no-ops. In dry-run mode nothing is written and the
+// planned changes are returned instead. The cluster is created with DefaultNetBoxClusterType when the spec sets
+// no cluster type. Returns netbox.ErrNotConfigured when the NetBox client is nil.
+func SyncClusterToNetBox(ctx context.Context, logger logr.Logger, nb *netbox.Client, status v1alpha1.ClusterInfoStatus, spec v1alpha1.NetBoxWriteBackSpec) (v1alpha1.NetBoxSync, error) {
+	if nb == nil {
+		return v1alpha1.NetBoxSync{}, netbox.ErrNotConfigured
+	}
+	if status.Name == "" {
+		return v1alpha1.NetBoxSync{}, errors.New("cluster name is unknown")
+	}
+
+	clusterType := spec.ClusterType
+	if clusterType == "" {
+		clusterType = DefaultNetBoxClusterType
+	}
+
+	w := &netBoxWriter{nb: nb, logger: logger, dryRun: spec.DryRun}
+	clusterID, err := w.ensureCluster(ctx, status.Name, clusterType)
+	if err != nil {
+		return v1alpha1.NetBoxSync{}, fmt.Errorf("failed to register cluster %s: %w", status.Name, err)
+	}
+
+	nodes := append([]v1alpha1.NodeInfo(nil), status.NodeInfo...)
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Name < nodes[j].Name
+	})
+	for _, node := range nodes {
+		if err := w.ensureNode(ctx, clusterID, node, status.Segments); err != nil {
+			return v1alpha1.NetBoxSync{}, fmt.Errorf("failed to register node %s: %w", node.Name, err)
+		}
+	}
+
+	if err := w.tagPrefixes(ctx, status.Name, status.Segments); err != nil {
+		return v1alpha1.NetBoxSync{}, fmt.Errorf("failed to tag prefixes: %w", err)
+	}
+
+	return v1alpha1.NetBoxSync{DryRun: spec.DryRun, Changes: w.changes}, nil
+}
+
+// ensureCluster returns the ID of the NetBox cluster with the given name, creating it when missing.
+// The returned ID is 0 when the cluster would be created in dry-run mode.
+func (w *netBoxWriter) ensureCluster(ctx context.Context, name, clusterType string) (int, error) {
+	clusters, err := netbox.List[NetBoxNestedObject](ctx, w.nb, NetBoxClustersPath, url.Values{"name": {name}})
+	if err != nil {
+		return 0, err
+	}
+	if len(clusters) > 0 {
+		return clusters[0].ID, nil
+	}
+
+	created := NetBoxNestedObject{}
+	body := map[string]any{
+		"name":   name,
+		"type":   map[string]any{"slug": clusterType},
+		"status": "active",
+	}
+	err = w.write(ctx, http.MethodPost, NetBoxClustersPath, body, &created, fmt.Sprintf("create cluster %s", name))
+	return created.ID, err
+}
+
+// ensureNode attaches the device or virtual machine of a node to the cluster and registers its internal IP.
+// Devices assigned to another cluster, or whose name matches several devices, are left untouched.
+func (w *netBoxWriter) ensureNode(ctx context.Context, clusterID int, node v1alpha1.NodeInfo, segments []v1alpha1.Segment) error {
+	host, isDevice, skipReason, err := w.findHost(ctx, clusterID, node.Name)
+	if err != nil {
+		return err
+	}
+	if skipReason != "" {
+		w.logger.Info(fmt.Sprintf("Not registering node %s in NetBox: %s", node.Name, skipReason))
+		return nil
+	}
+
+	hostPath := NetBoxVirtualMachinesPath
+	if isDevice {
+		hostPath = NetBoxDevicesPath
+	}
+
+	switch {
+	case host.ID == 0:
+		created := NetBoxHost{}
+		body := map[string]any{"name": node.Name, "cluster": clusterID, "status": "active"}
+		if err := w.write(ctx, http.MethodPost, hostPath, body, &created, fmt.Sprintf("create virtual machine %s", node.Name)); err != nil {
+			return err
+		}
+		host = created
+	case host.Cluster == nil:
+		change := fmt.Sprintf("assign %s to the cluster", node.Name)
+		if err := w.write(ctx, http.MethodPatch, itemPath(hostPath, host.ID), map[string]any{"cluster": clusterID}, nil, change); err != nil {
+			return err
+		}
+	}
+
+	if node.InternalIP == "" {
+		return nil
+	}
+	return w.ensureIPAddress(ctx, host, isDevice, node, segments)
+}
+
+// findHost looks up the device named after a node, preferring the devices of the cluster over the unassigned ones,
+// and falls back to the cluster virtual machine. An empty host is returned when neither exists. The reason to skip
+// the node is returned instead when the matching device belongs to another cluster or the name is ambiguous.
+func (w *netBoxWriter) findHost(ctx context.Context, clusterID int, name string) (NetBoxHost, bool, string, error) {
+	devices, err := netbox.List[NetBoxHost](ctx, w.nb, NetBoxDevicesPath, url.Values{"name": {name}})
+	if err != nil {
+		return NetBoxHost{}, false, "", err
+	}
+	var inCluster, unassigned []NetBoxHost
+	for _, device := range devices {
+		switch {
+		case device.Cluster == nil:
+			unassigned = append(unassigned, device)
+		case device.Cluster.ID == clusterID:
+			inCluster = append(inCluster, device)
+		}
+	}
+	switch {
+	case len(inCluster) == 1:
+		return inCluster[0], true, "", nil
+	case len(inCluster) > 1:
+		return NetBoxHost{}, false, fmt.Sprintf("%d devices of the cluster are named %s", len(inCluster), name), nil
+	case len(unassigned) == 1:
+		return unassigned[0], true, "", nil
+	case len(unassigned) > 1:
+		return NetBoxHost{}, false, fmt.Sprintf("%d unassigned devices are named %s", len(unassigned), name), nil
+	case len(devices) > 0:
+		return NetBoxHost{}, false, fmt.Sprintf("device %s is assigned to NetBox cluster %d", name, devices[0].Cluster.ID), nil
+	}
+
+	if clusterID == 0 {
+		return NetBoxHost{}, false, "", nil
+	}
+	vms, err := netbox.List[NetBoxHost](ctx, w.nb, NetBoxVirtualMachinesPath, url.Values{
+		"name":       {name},
+		"cluster_id": {strconv.Itoa(clusterID)},
+	})
+	if err != nil || len(vms) == 0 {
+		return NetBoxHost{}, false, "", err
+	}
+	return vms[0], false, "", nil
+}
+
+// ensureIPAddress registers the node internal IP in the VRF of the segment containing it, assigns it to the host
+// interface and makes it the host primary IP. Device interfaces are never created, the address is left unassigned
+// when the device has no matching interface. An address already assigned to another interface is left untouched.
+func (w *netBoxWriter) ensureIPAddress(ctx context.Context, host NetBoxHost, isDevice bool, node v1alpha1.NodeInfo, segments []v1alpha1.Segment) error {
+	interfaceID, err := w.ensureInterface(ctx, host, isDevice, nodeInterfaceName(node, segments))
+	if err != nil {
+		return err
+	}
+	interfaceType := netBoxVMInterfaceType
+	if isDevice {
+		interfaceType = netBoxDeviceInterfaceType
+	}
+
+	vrfID := 0
+	if vrf := nodeVRF(node.InternalIP, segments); vrf != "" {
+		if vrfID, err = w.findVRF(ctx, vrf); err != nil {
+			return err
+		}
+	}
+	ips, err := netbox.List[NetBoxIPAddress](ctx, w.nb, NetBoxIPAddressesPath, url.Values{"address": {node.InternalIP}})
+	if err != nil {
+		return err
+	}
+	ip, found := NetBoxIPAddress{}, false
+	for _, candidate := range ips {
+		if nestedObjectID(candidate.VRF) == vrfID {
+			ip, found = candidate, true
+			break
+		}
+	}
+
+	switch {
+	case !found:
+		body := map[string]any{"address": nodeAddress(node.InternalIP, segments), "status": "active"}
+		if vrfID != 0 {
+			body["vrf"] = vrfID
+		}
+		if interfaceID != 0 {
+			body["assigned_object_type"] = interfaceType
+			body["assigned_object_id"] = interfaceID
+		}
+		change := fmt.Sprintf("create IP address %s for %s", body["address"], node.Name)
+		if err := w.write(ctx, http.MethodPost, NetBoxIPAddressesPath, body, &ip, change); err != nil {
+			return err
+		}
+	case interfaceID == 0 || (ip.AssignedObjectType == interfaceType && ip.AssignedObjectID == interfaceID):
+		// Nothing to assign the address to, or it is already assigned to the host interface.
+	case ip.AssignedObjectID == 0:
+		body := map[string]any{"assigned_object_type": interfaceType, "assigned_object_id": interfaceID}
+		change := fmt.Sprintf("assign IP address %s to %s", ip.Address, node.Name)
+		if err := w.write(ctx, http.MethodPatch, itemPath(NetBoxIPAddressesPath, ip.ID), body, nil, change); err != nil {
+			return err
+		}
+	default:
+		w.logger.Info(fmt.Sprintf("IP address %s is assigned to %s %d in NetBox, not assigning it to %s",
+			ip.Address, ip.AssignedObjectType, ip.AssignedObjectID, node.Name))
+		return nil
+	}
+
+	if host.ID == 0 || ip.ID == 0 || interfaceID == 0 {
+		return nil
+	}
+	primaryField, primary := "primary_ip4", host.PrimaryIP4
+	if net.ParseIP(node.InternalIP).To4() == nil {
+		primaryField, primary = "primary_ip6", host.PrimaryIP6
+	}
+	if primary != nil && primary.ID == ip.ID {
+		return nil
+	}
+	hostPath := NetBoxVirtualMachinesPath
+	if isDevice {
+		hostPath = NetBoxDevicesPath
+	}
+	change := fmt.Sprintf("set %s of %s to %s", primaryField, node.Name, node.InternalIP)
+	return w.write(ctx, http.MethodPatch, itemPath(hostPath, host.ID), map[string]any{primaryField: ip.ID}, nil, change)
+}
+
+// ensureInterface returns the ID of the named host interface, creating it on virtual machines when missing.
+// It returns 0 when the host does not exist yet or a device has no such interface.
+func (w *netBoxWriter) ensureInterface(ctx context.Context, host NetBoxHost, isDevice bool, name string) (int, error) {
+	if host.ID == 0 {
+		return 0, nil
+	}
+
+	path, hostFilter := NetBoxVMInterfacesPath, "virtual_machine_id"
+	if isDevice {
+		path, hostFilter = NetBoxDeviceInterfacesPath, "device_id"
+	}
+	interfaces, err := netbox.List[NetBoxNestedObject](ctx, w.nb, path, url.Values{
+		hostFilter: {strconv.Itoa(host.ID)},
+		"name":     {name},
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(interfaces) > 0 {
+		return interfaces[0].ID, nil
+	}
+	if isDevice {
+		return 0, nil
+	}
+
+	created := NetBoxNestedObject{}
+	body := map[string]any{"virtual_machine": host.ID, "name": name}
+	err = w.write(ctx, http.MethodPost, path, body, &created, fmt.Sprintf("create interface %s on %s", name, host.Name))
+	return created.ID, err
+}
+
+// findVRF returns the ID of the NetBox VRF with the given name.
+func (w *netBoxWriter) findVRF(ctx context.Context, name string) (int, error) {
+	vrfs, err := netbox.List[NetBoxNestedObject](ctx, w.nb, NetBoxVRFsPath, url.Values{"name": {name}})
+	if err != nil {
+		return 0, err
+	}
+	if len(vrfs) == 0 {
+		return 0, fmt.Errorf("VRF %s not found", name)
+	}
+	return vrfs[0].ID, nil
+}
+
+// tagPrefixes sets the Cluster custom field on the NetBox prefixes matching the cluster segments.
+// Prefixes already assigned to another cluster are left untouched.
+func (w *netBoxWriter) tagPrefixes(ctx context.Context, clusterName string, segments []v1alpha1.Segment) error {
+	for start := 0; start < len(segments); start += netBoxPrefixBatchSize {
+		var batch []string
+		for _, segment := range segments[start:min(start+netBoxPrefixBatchSize, len(segments))] {
+			batch = append(batch, segment.Prefix)
+		}
+
+		results, err := netbox.List[NetBoxResult](ctx, w.nb, ApiPrefix, url.Values{"prefix": batch})
+		if err != nil {
+			return err
+		}
+		for _, prefix := range results {
+			switch prefix.CustomFields.Cluster {
+			case clusterName:
+				continue
+			case "":
+				body := map[string]any{"custom_fields": map[string]any{"Cluster": clusterName}}
+				change := fmt.Sprintf("tag prefix %s with cluster %s", prefix.Prefix, clusterName)
+				if err := w.write(ctx, http.MethodPatch, itemPath(ApiPrefix, prefix.ID), body, nil, change); err != nil {
+					return err
+				}
+			default:
+				w.logger.Info(fmt.Sprintf("Prefix %s is assigned to cluster %s in NetBox, not tagging it", prefix.Prefix, prefix.CustomFields.Cluster))
+			}
+		}
+	}
+	return nil
+}
+
+// nodeAddress returns the node IP with the prefix length of the segment containing it,
+// or as a host address when no segment contains it.
+func nodeAddress(ip string, segments []v1alpha1.Segment) string {
+	parsed := net.ParseIP(ip)
+	for _, segment := range segments {
+		if _, ipNet, err := net.ParseCIDR(segment.Prefix); err == nil && ipNet.Contains(parsed) {
+			ones, _ := ipNet.Mask.Size()
+			return fmt.Sprintf("%s/%d", ip, ones)
+		}
+	}
+	if parsed.To4() == nil {
+		return ip + "/128"
+	}
+	return ip + "/32"
+}
+
+// nodeVRF returns the VRF of the segment containing the node IP, empty for the global table.
+func nodeVRF(ip string, segments []v1alpha1.Segment) string {
+	parsed := net.ParseIP(ip)
+	for _, segment := range segments {
+		if _, ipNet, err := net.ParseCIDR(segment.Prefix); err == nil && ipNet.Contains(parsed) {
+			return segment.VRF
+		}
+	}
+	return ""
+}
+
+// nestedObjectID returns the ID of a nested NetBox object, 0 when it is not set.
+func nestedObjectID(obj *NetBoxNestedObject) int {
+	if obj == nil {
+		return 0
+	}
+	return obj.ID
+}
+
+// nodeInterfaceName returns the interface holding the node IP when the segment containing it
+// is reached through a single interface name.
+func nodeInterfaceName(node v1alpha1.NodeInfo, segments []v1alpha1.Segment) string {
+	parsed := net.ParseIP(node.InternalIP)
+	for _, segment := range segments {
+		_, ipNet, err := net.ParseCIDR(segment.Prefix)
+		if err != nil || !ipNet.Contains(parsed) || len(segment.Interfaces) != 1 {
+			continue
+		}
+		return segment.Interfaces[0]
+	}
+	return defaultNetBoxInterface
+}
+
+func itemPath(path string, id int) string {
+	return fmt.Sprintf("%s%d/", path, id)
+}
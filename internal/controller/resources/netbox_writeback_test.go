@@ -0,0 +1,163 @@
+package resources
+
+import (
+	"context"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/dana-team/axiom-operator/api/v1alpha1"
+	"github.com/dana-team/axiom-operator/internal/netbox"
+	"github.com/dana-team/axiom-operator/internal/netbox/netboxtest"
+)
+
+var _ = Describe("NetBox write-back", func() {
+	const clusterName = "ocp.example.com"
+
+	var (
+		ctx    context.Context
+		server *netboxtest.Server
+		nb     *netbox.Client
+		status v1alpha1.ClusterInfoStatus
+	)
+
+	writes := func() []string {
+		var out []string
+		for _, request := range server.Requests() {
+			if !strings.HasPrefix(request, "GET ") {
+				out = append(out, request)
+			}
+		}
+		return out
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		server = netboxtest.NewServer("token")
+		DeferCleanup(server.Close)
+
+		var err error
+		nb, err = netbox.NewClient(netbox.Config{
+			URL:               server.URL,
+			Token:             "token",
+			CABundle:          server.CABundle(),
+			RequestsPerSecond: 1000,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		deviceID := server.Add(NetBoxDevicesPath, map[string]any{"name": "master-0"})
+		server.Add(NetBoxDeviceInterfacesPath, map[string]any{"name": "ens3", "device": map[string]any{"id": deviceID}})
+		server.Add(ApiPrefix, map[string]any{"prefix": "10.0.0.0/24", "custom_fields": map[string]any{"Cluster": nil}})
+		server.Add(ApiPrefix, map[string]any{"prefix": "10.0.1.0/24", "custom_fields": map[string]any{"Cluster": "other"}})
+
+		status = v1alpha1.ClusterInfoStatus{
+			Name: clusterName,
+			NodeInfo: []v1alpha1.NodeInfo{
+				{Name: "worker-0", InternalIP: "10.0.0.11"},
+				{Name: "master-0", InternalIP: "10.0.0.10"},
+			},
+			Segments: []v1alpha1.Segment{
+				{Prefix: "10.0.0.0/24", Interfaces: []string{"ens3"}},
+				{Prefix: "10.0.1.0/24"},
+			},
+		}
+	})
+
+	It("should only plan the changes in dry-run mode", func() {
+		sync, err := SyncClusterToNetBox(ctx, log.Log, nb, status, v1alpha1.NetBoxWriteBackSpec{DryRun: true, ClusterType: "openshift"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sync.DryRun).To(BeTrue())
+		Expect(sync.Changes).To(ContainElements(
+			"create cluster "+clusterName,
+			"create virtual machine worker-0",
+			"tag prefix 10.0.0.0/24 with cluster "+clusterName,
+		))
+		Expect(writes()).To(BeEmpty())
+	})
+
+	It("should register the cluster once and be idempotent", func() {
+		spec := v1alpha1.NetBoxWriteBackSpec{ClusterType: "openshift"}
+		sync, err := SyncClusterToNetBox(ctx, log.Log, nb, status, spec)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sync.Changes).NotTo(BeEmpty())
+
+		Expect(server.Objects(NetBoxClustersPath)).To(HaveLen(1))
+		Expect(server.Objects(NetBoxVirtualMachinesPath)).To(ConsistOf(HaveKeyWithValue("name", "worker-0")))
+		Expect(server.Objects(NetBoxDevicesPath)[0]).To(HaveKey("cluster"))
+		Expect(server.Objects(NetBoxIPAddressesPath)).To(ConsistOf(
+			HaveKeyWithValue("address", "10.0.0.10/24"),
+			HaveKeyWithValue("address", "10.0.0.11/24"),
+		))
+		prefixes := server.Objects(ApiPrefix)
+		Expect(prefixes[0]["custom_fields"]).To(HaveKeyWithValue("Cluster", clusterName))
+		Expect(prefixes[1]["custom_fields"]).To(HaveKeyWithValue("Cluster", "other"))
+
+		sync, err = SyncClusterToNetBox(ctx, log.Log, nb, status, spec)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sync.Changes).To(BeEmpty())
+	})
+	It("should default the cluster type", func() {
+		_, err := SyncClusterToNetBox(ctx, log.Log, nb, status, v1alpha1.NetBoxWriteBackSpec{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(server.Objects(NetBoxClustersPath)).To(ConsistOf(
+			HaveKeyWithValue("type", HaveKeyWithValue("slug", DefaultNetBoxClusterType)),
+		))
+	})
+
+	It("should not reassign an IP address assigned to another interface", func() {
+		otherID := server.Add(NetBoxDeviceInterfacesPath, map[string]any{"name": "eth0"})
+		server.Add(NetBoxIPAddressesPath, map[string]any{
+			"address":              "10.0.0.10/24",
+			"assigned_object_type": netBoxDeviceInterfaceType,
+			"assigned_object_id":   otherID,
+		})
+
+		_, err := SyncClusterToNetBox(ctx, log.Log, nb, status, v1alpha1.NetBoxWriteBackSpec{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(server.Objects(NetBoxIPAddressesPath)).To(ContainElement(SatisfyAll(
+			HaveKeyWithValue("address", "10.0.0.10/24"),
+			HaveKeyWithValue("assigned_object_id", otherID),
+		)))
+		Expect(server.Objects(NetBoxDevicesPath)[0]).NotTo(HaveKey("primary_ip4"))
+	})
+
+	It("should register the IP addresses in the VRF of their segment", func() {
+		vrfID := server.Add(NetBoxVRFsPath, map[string]any{"name": "prod"})
+		server.Add(NetBoxIPAddressesPath, map[string]any{"address": "10.0.0.11/24"})
+		status.Segments[0].VRF = "prod"
+
+		_, err := SyncClusterToNetBox(ctx, log.Log, nb, status, v1alpha1.NetBoxWriteBackSpec{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(server.Objects(NetBoxIPAddressesPath)).To(ConsistOf(
+			SatisfyAll(HaveKeyWithValue("address", "10.0.0.11/24"), Not(HaveKey("vrf"))),
+			SatisfyAll(HaveKeyWithValue("address", "10.0.0.10/24"), HaveKeyWithValue("vrf", HaveKeyWithValue("id", vrfID))),
+			SatisfyAll(HaveKeyWithValue("address", "10.0.0.11/24"), HaveKeyWithValue("vrf", HaveKeyWithValue("id", vrfID))),
+		))
+
+		sync, err := SyncClusterToNetBox(ctx, log.Log, nb, status, v1alpha1.NetBoxWriteBackSpec{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sync.Changes).To(BeEmpty())
+	})
+	It("should leave the devices of other clusters and ambiguous device names untouched", func() {
+		otherClusterID := server.Add(NetBoxClustersPath, map[string]any{"name": "other"})
+		server.Add(NetBoxDevicesPath, map[string]any{"name": "worker-0", "cluster": map[string]any{"id": otherClusterID}})
+		server.Add(NetBoxDevicesPath, map[string]any{"name": "worker-1"})
+		server.Add(NetBoxDevicesPath, map[string]any{"name": "worker-1"})
+		status.NodeInfo = append(status.NodeInfo, v1alpha1.NodeInfo{Name: "worker-1", InternalIP: "10.0.0.12"})
+
+		_, err := SyncClusterToNetBox(ctx, log.Log, nb, status, v1alpha1.NetBoxWriteBackSpec{})
+		Expect(err).NotTo(HaveOccurred())
+		for _, device := range server.Objects(NetBoxDevicesPath) {
+			switch device["name"] {
+			case "worker-0":
+				Expect(device).To(HaveKeyWithValue("cluster", HaveKeyWithValue("id", otherClusterID)))
+			case "worker-1":
+				Expect(device).NotTo(HaveKey("cluster"))
+			}
+		}
+		Expect(server.Objects(NetBoxVirtualMachinesPath)).To(BeEmpty())
+		Expect(server.Objects(NetBoxIPAddressesPath)).To(ConsistOf(HaveKeyWithValue("address", "10.0.0.10/24")))
+	})
+})
@@ -0,0 +1,108 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/dana-team/axiom-operator/api/v1alpha1"
+	"github.com/dana-team/axiom-operator/internal/controller/common"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// mainRouteTableID is the ID of the main routing table, reported by nmstate as 254 or omitted.
+	mainRouteTableID = 254
+)
+
+// ignoredInterfaceTypes are the interface types left out of the inventory, such as the veth pairs created for pods.
+var ignoredInterfaceTypes = map[string]struct{}{
+	"veth":     {},
+	"loopback": {},
+}
+
+// GetNetworkInterfaces retrieves the network interfaces, main table routes and default gateways of each provided node
+// from its NodeNetworkState. Nodes without a NodeNetworkState are skipped.
+// Returns the inventory ordered by node or an error if any NodeNetworkState cannot be read.
+func GetNetworkInterfaces(ctx context.Context, logger logr.Logger, k8sClient client.Client, nodes []corev1.Node) ([]v1alpha1.NodeNetworkInterfaces, error) {
+	var inventory []v1alpha1.NodeNetworkInterfaces
+	for _, node := range nodes {
+		state, err := getNodeNetworkState(ctx, logger, k8sClient, node.Name)
+		if err != nil {
+			return nil, err
+		}
+		if state == nil {
+			continue
+		}
+		inventory = append(inventory, nodeNetworkInterfaces(node.Name, *state))
+	}
+
+	sort.Slice(inventory, func(i, j int) bool {
+		return inventory[i].Node < inventory[j].Node
+	})
+	return inventory, nil
+}
+
+// nodeNetworkInterfaces converts the network state of a node to its interface inventory.
+func nodeNetworkInterfaces(nodeName string, state NodeNetworkStateCurrentState) v1alpha1.NodeNetworkInterfaces {
+	inventory := v1alpha1.NodeNetworkInterfaces{Node: nodeName}
+
+	for _, iface := range state.Interfaces {
+		if _, ok := ignoredInterfaceTypes[iface.Type]; ok || iface.Name == "lo" {
+			continue
+		}
+
+		networkInterface := v1alpha1.NetworkInterface{
+			Name:       iface.Name,
+			Type:       iface.Type,
+			State:      iface.State,
+			MTU:        iface.MTU,
+			MACAddress: iface.MACAddress,
+		}
+		if iface.LinkAggregation != nil {
+			networkInterface.BondMode = iface.LinkAggregation.Mode
+			networkInterface.BondPorts = append(append([]string{}, iface.LinkAggregation.Port...), iface.LinkAggregation.Slaves...)
+			sort.Strings(networkInterface.BondPorts)
+		}
+		if iface.VLAN != nil {
+			networkInterface.VLANBaseInterface = iface.VLAN.BaseIface
+			networkInterface.VLANID = iface.VLAN.ID
+		}
+		for _, address := range append(append([]Address{}, iface.Ipv4.Address...), iface.Ipv6.Address...) {
+			if address.IP != "" {
+				networkInterface.Addresses = append(networkInterface.Addresses, fmt.Sprintf("%s/%d", address.IP, address.PrefixLength))
+			}
+		}
+		inventory.Interfaces = append(inventory.Interfaces, networkInterface)
+	}
+	sort.Slice(inventory.Interfaces, func(i, j int) bool {
+		return inventory.Interfaces[i].Name < inventory.Interfaces[j].Name
+	})
+
+	for _, route := range state.Routes.Running {
+		if route.TableID != 0 && route.TableID != mainRouteTableID {
+			continue
+		}
+		inventory.Routes = append(inventory.Routes, v1alpha1.NetworkRoute{
+			Destination:      route.Destination,
+			NextHopAddress:   route.NextHopAddress,
+			NextHopInterface: route.NextHopInterface,
+			Metric:           route.Metric,
+		})
+		if (route.Destination == "0.0.0.0/0" || route.Destination == "::/0") && route.NextHopAddress != "" {
+			inventory.DefaultGateways = append(inventory.DefaultGateways, route.NextHopAddress)
+		}
+	}
+	sort.SliceStable(inventory.Routes, func(i, j int) bool {
+		if inventory.Routes[i].Destination != inventory.Routes[j].Destination {
+			return inventory.Routes[i].Destination < inventory.Routes[j].Destination
+		}
+		return inventory.Routes[i].Metric < inventory.Routes[j].Metric
+	})
+	inventory.DefaultGateways = common.FilterUniqueStrings(inventory.DefaultGateways)
+	sort.Strings(inventory.DefaultGateways)
+
+	return inventory
+}
@@ -0,0 +1,63 @@
+package resources
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v3"
+
+	"github.com/dana-team/axiom-operator/api/v1alpha1"
+)
+
+var _ = Describe("Network interfaces", func() {
+	const currentState = `
+interfaces:
+- name: bond0
+  type: bond
+  state: up
+  mtu: 9000
+  mac-address: 52:54:00:00:00:01
+  link-aggregation:
+    mode: 802.3ad
+    port: [ens2, ens1]
+- name: bond0.100
+  type: vlan
+  state: up
+  mtu: 9000
+  vlan:
+    base-iface: bond0
+    id: 100
+  ipv4:
+    address:
+    - ip: 10.0.0.10
+      prefix-length: 24
+- name: veth1234
+  type: veth
+  state: up
+routes:
+  running:
+  - destination: 0.0.0.0/0
+    next-hop-address: 10.0.0.1
+    next-hop-interface: bond0.100
+    metric: 300
+    table-id: 254
+  - destination: 10.200.0.0/16
+    next-hop-address: 10.0.0.254
+    next-hop-interface: bond0.100
+    table-id: 100
+`
+
+	It("should report bonds, VLANs, main table routes and default gateways", func() {
+		var state NodeNetworkStateCurrentState
+		Expect(yaml.Unmarshal([]byte(currentState), &state)).To(Succeed())
+
+		inventory := nodeNetworkInterfaces("worker-0", state)
+		Expect(inventory.Interfaces).To(Equal([]v1alpha1.NetworkInterface{
+			{Name: "bond0", Type: "bond", State: "up", MTU: 9000, MACAddress: "52:54:00:00:00:01", BondMode: "802.3ad", BondPorts: []string{"ens1", "ens2"}},
+			{Name: "bond0.100", Type: "vlan", State: "up", MTU: 9000, VLANBaseInterface: "bond0", VLANID: 100, Addresses: []string{"10.0.0.10/24"}},
+		}))
+		Expect(inventory.Routes).To(Equal([]v1alpha1.NetworkRoute{
+			{Destination: "0.0.0.0/0", NextHopAddress: "10.0.0.1", NextHopInterface: "bond0.100", Metric: 300},
+		}))
+		Expect(inventory.DefaultGateways).To(Equal([]string{"10.0.0.1"}))
+	})
+})
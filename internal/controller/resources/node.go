@@ -23,6 +23,17 @@ func GetClusterNodes(ctx context.Context, logger logr.Logger, k8sClient client.C
 	return nodeList.Items, nil
 }
 
+// GetPods retrieves all pods of the cluster through apiReader, so that the pods are not cached cluster-wide.
+// The pods are listed once per reconcile and shared by the workload summary and the resource utilization.
+func GetPods(ctx context.Context, logger logr.Logger, apiReader client.Reader) ([]corev1.Pod, error) {
+	podList := &corev1.PodList{}
+	if err := apiReader.List(ctx, podList); err != nil {
+		logger.Error(err, "Failed to list Pods")
+		return nil, err
+	}
+	return podList.Items, nil
+}
+
 // CalculateClusterCompute calculates total compute resources (CPU, Memory, Storage, Pods, GPU) across all provided nodes
 func CalculateClusterCompute(nodes []corev1.Node) v1alpha1.ClusterResources {
 	totalCPU := resource.NewQuantity(0, resource.BinarySI)
@@ -2,27 +2,91 @@ package resources
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/dana-team/axiom-operator/api/v1alpha1"
+	"github.com/dana-team/axiom-operator/internal/controller/common"
 
 	"github.com/go-logr/logr"
 	configv1 "github.com/openshift/api/config/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// GetIdentityProviders retrieves a list of identity provider names from the cluster OAuth configuration.
-// The function fetches the OAuth custom resource and extracts the names of all configured identity providers.
-func GetIdentityProviders(ctx context.Context, logger logr.Logger, k8sClient client.Client, ci *v1alpha1.ClusterInfo) ([]string, error) {
+// GetIdentityProviders retrieves the identity providers of the cluster. Standalone clusters take them from the
+// cluster OAuth configuration, and hosted clusters from spec.configuration.oauth of their HostedCluster on the
+// management cluster, whose kubeconfig Secret is read through apiReader and whose client is kept in
+// managementClients. Plain Kubernetes clusters and clusters without the OAuth API have no identity providers.
+func GetIdentityProviders(ctx context.Context, logger logr.Logger, k8sClient client.Client, apiReader client.Reader, managementClients *ManagementClientCache, ci *v1alpha1.ClusterInfo, distribution string, hosted bool) ([]v1alpha1.IdentityProvider, error) {
+	if !IsOpenShift(distribution) {
+		return nil, nil
+	}
+	if hosted {
+		return getHostedClusterIdentityProviders(ctx, logger, apiReader, managementClients, ci)
+	}
+
 	oauth := &configv1.OAuth{}
-	if !ci.Spec.HostedCluster {
-		if err := k8sClient.Get(ctx, client.ObjectKey{Name: "cluster"}, oauth); err != nil {
-			logger.Error(err, "Failed to get cluster OAuth")
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: "cluster"}, oauth); err != nil {
+		if common.IsAPINotAvailable(err) {
+			logger.Info("OAuth is not available, skipping identity providers")
+			return nil, nil
+		}
+		logger.Error(err, "Failed to get cluster OAuth")
+		return nil, err
+	}
+	return identityProvidersFromOAuth(oauth.Spec), nil
+}
+
+// getHostedClusterIdentityProviders reads the identity providers from the OAuth configuration of the HostedCluster.
+func getHostedClusterIdentityProviders(ctx context.Context, logger logr.Logger, apiReader client.Reader, managementClients *ManagementClientCache, ci *v1alpha1.ClusterInfo) ([]v1alpha1.IdentityProvider, error) {
+	hostedCluster, err := GetHostedCluster(ctx, logger, apiReader, managementClients, ci)
+	if err != nil {
+		return nil, err
+	}
+
+	oauthSpec := configv1.OAuthSpec{}
+	rawOAuth, found, err := unstructured.NestedMap(hostedCluster.Object, "spec", "configuration", "oauth")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the OAuth configuration of HostedCluster %s: %w", hostedCluster.GetName(), err)
+	}
+	if found {
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(rawOAuth, &oauthSpec); err != nil {
+			return nil, fmt.Errorf("failed to convert the OAuth configuration of HostedCluster %s: %w", hostedCluster.GetName(), err)
+		}
+	}
+	return identityProvidersFromOAuth(oauthSpec), nil
+}
+
+// identityProvidersFromOAuth returns the name, type, mapping method and URL of the identity providers of an OAuth
+// configuration. Client secrets and other credentials are never copied.
+func identityProvidersFromOAuth(spec configv1.OAuthSpec) []v1alpha1.IdentityProvider {
+	idps := []v1alpha1.IdentityProvider{}
+	for _, provider := range spec.IdentityProviders {
+		idp := v1alpha1.IdentityProvider{
+			Name:          provider.Name,
+			Type:          string(provider.Type),
+			MappingMethod: string(provider.MappingMethod),
 		}
-		idps := []string{}
-		for _, provider := range oauth.Spec.IdentityProviders {
-			idps = append(idps, provider.Name)
+		switch {
+		case provider.OpenID != nil:
+			idp.URL = provider.OpenID.Issuer
+		case provider.LDAP != nil:
+			idp.URL = provider.LDAP.URL
+		case provider.Keystone != nil:
+			idp.URL = provider.Keystone.URL
+		case provider.BasicAuth != nil:
+			idp.URL = provider.BasicAuth.URL
+		case provider.GitHub != nil:
+			idp.URL = provider.GitHub.Hostname
+		case provider.GitLab != nil:
+			idp.URL = provider.GitLab.URL
+		case provider.Google != nil:
+			idp.URL = provider.Google.HostedDomain
+		case provider.RequestHeader != nil:
+			idp.URL = provider.RequestHeader.LoginURL
 		}
-		return idps, nil
+		idps = append(idps, idp)
 	}
-	return []string{"mce configured IDP"}, nil
+	return idps
 }
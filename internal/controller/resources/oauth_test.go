@@ -0,0 +1,36 @@
+package resources
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	configv1 "github.com/openshift/api/config/v1"
+
+	"github.com/dana-team/axiom-operator/api/v1alpha1"
+)
+
+var _ = Describe("Identity providers", func() {
+	It("should report the type, mapping method and URL of the identity providers", func() {
+		spec := configv1.OAuthSpec{IdentityProviders: []configv1.IdentityProvider{
+			{
+				Name:          "sso",
+				MappingMethod: configv1.MappingMethodClaim,
+				IdentityProviderConfig: configv1.IdentityProviderConfig{
+					Type:   configv1.IdentityProviderTypeOpenID,
+					OpenID: &configv1.OpenIDIdentityProvider{Issuer: "https://sso.example.com", ClientID: "ocp"},
+				},
+			},
+			{
+				Name: "local",
+				IdentityProviderConfig: configv1.IdentityProviderConfig{
+					Type:     configv1.IdentityProviderTypeHTPasswd,
+					HTPasswd: &configv1.HTPasswdIdentityProvider{},
+				},
+			},
+		}}
+
+		Expect(identityProvidersFromOAuth(spec)).To(Equal([]v1alpha1.IdentityProvider{
+			{Name: "sso", Type: "OpenID", MappingMethod: "claim", URL: "https://sso.example.com"},
+			{Name: "local", Type: "HTPasswd"},
+		}))
+	})
+})
@@ -0,0 +1,94 @@
+package resources
+
+import (
+	"context"
+	"sort"
+
+	"github.com/dana-team/axiom-operator/api/v1alpha1"
+	"github.com/dana-team/axiom-operator/internal/controller/common"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// olmCopiedFromLabel marks the copies of a ClusterServiceVersion made by OLM in the namespaces it watches.
+const olmCopiedFromLabel = "olm.copiedFrom"
+
+// SubscriptionListGVK and ClusterServiceVersionListGVK are the kinds of the OLM lists, read as unstructured objects.
+var (
+	SubscriptionListGVK          = schema.GroupVersionKind{Group: "operators.coreos.com", Version: "v1alpha1", Kind: "SubscriptionList"}
+	ClusterServiceVersionListGVK = schema.GroupVersionKind{Group: "operators.coreos.com", Version: "v1alpha1", Kind: "ClusterServiceVersionList"}
+)
+
+// GetOLMOperators retrieves the operators installed by OLM Subscriptions, together with the version and phase of
+// their installed ClusterServiceVersion. Clusters without OLM have no OLM operators. The OLM objects are large,
+// so they are listed through apiReader instead of being cached, leaving out the copied ClusterServiceVersions.
+func GetOLMOperators(ctx context.Context, logger logr.Logger, apiReader client.Reader) ([]v1alpha1.OLMOperator, error) {
+	subscriptions := &unstructured.UnstructuredList{}
+	subscriptions.SetGroupVersionKind(SubscriptionListGVK)
+	if err := apiReader.List(ctx, subscriptions); err != nil {
+		if common.IsAPINotAvailable(err) {
+			logger.Info("OLM is not available, skipping OLM operators")
+			return nil, nil
+		}
+		logger.Error(err, "Failed to list Subscriptions")
+		return nil, err
+	}
+
+	csvs := &unstructured.UnstructuredList{}
+	csvs.SetGroupVersionKind(ClusterServiceVersionListGVK)
+	notCopied, err := labels.NewRequirement(olmCopiedFromLabel, selection.DoesNotExist, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := apiReader.List(ctx, csvs, client.MatchingLabelsSelector{Selector: labels.NewSelector().Add(*notCopied)}); err != nil {
+		if common.IsAPINotAvailable(err) {
+			logger.Info("OLM is not available, skipping OLM operators")
+			return nil, nil
+		}
+		logger.Error(err, "Failed to list ClusterServiceVersions")
+		return nil, err
+	}
+
+	csvsByKey := make(map[types.NamespacedName]unstructured.Unstructured, len(csvs.Items))
+	for _, csv := range csvs.Items {
+		csvsByKey[types.NamespacedName{Namespace: csv.GetNamespace(), Name: csv.GetName()}] = csv
+	}
+
+	olmOperators := make([]v1alpha1.OLMOperator, 0, len(subscriptions.Items))
+	for _, subscription := range subscriptions.Items {
+		olmOperator := olmOperatorFromSubscription(subscription)
+		if csv, ok := csvsByKey[types.NamespacedName{Namespace: olmOperator.Namespace, Name: olmOperator.InstalledCSV}]; ok {
+			olmOperator.Version, _, _ = unstructured.NestedString(csv.Object, "spec", "version")
+			olmOperator.Phase, _, _ = unstructured.NestedString(csv.Object, "status", "phase")
+		}
+		olmOperators = append(olmOperators, olmOperator)
+	}
+
+	sort.Slice(olmOperators, func(i, j int) bool {
+		if olmOperators[i].Namespace != olmOperators[j].Namespace {
+			return olmOperators[i].Namespace < olmOperators[j].Namespace
+		}
+		return olmOperators[i].Name < olmOperators[j].Name
+	})
+	return olmOperators, nil
+}
+
+// olmOperatorFromSubscription returns the package, channel, catalog, approval and installed CSV of a Subscription.
+func olmOperatorFromSubscription(subscription unstructured.Unstructured) v1alpha1.OLMOperator {
+	olmOperator := v1alpha1.OLMOperator{
+		Name:      subscription.GetName(),
+		Namespace: subscription.GetNamespace(),
+	}
+	olmOperator.Package, _, _ = unstructured.NestedString(subscription.Object, "spec", "name")
+	olmOperator.Channel, _, _ = unstructured.NestedString(subscription.Object, "spec", "channel")
+	olmOperator.CatalogSource, _, _ = unstructured.NestedString(subscription.Object, "spec", "source")
+	olmOperator.InstallPlanApproval, _, _ = unstructured.NestedString(subscription.Object, "spec", "installPlanApproval")
+	olmOperator.InstalledCSV, _, _ = unstructured.NestedString(subscription.Object, "status", "installedCSV")
+	return olmOperator
+}
@@ -0,0 +1,83 @@
+package resources
+
+import (
+	"context"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta/testrestmapper"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("OLMOperators", func() {
+	It("should skip clusters without OLM", func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		k8sClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithRESTMapper(testrestmapper.TestOnlyStaticRESTMapper(scheme)).
+			Build()
+
+		olmOperators, err := GetOLMOperators(context.Background(), log.Log, k8sClient)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(olmOperators).To(BeEmpty())
+	})
+
+	It("should report the version and phase of the installed ClusterServiceVersion", func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		for _, gvk := range []schema.GroupVersionKind{SubscriptionListGVK, ClusterServiceVersionListGVK} {
+			scheme.AddKnownTypeWithName(gvk, &unstructured.UnstructuredList{})
+			scheme.AddKnownTypeWithName(gvk.GroupVersion().WithKind(strings.TrimSuffix(gvk.Kind, "List")), &unstructured.Unstructured{})
+		}
+		object := func(kind, namespace string, labels map[string]any, spec, status map[string]any) *unstructured.Unstructured {
+			obj := &unstructured.Unstructured{Object: map[string]any{
+				"metadata": map[string]any{"name": "cert-manager", "namespace": namespace, "labels": labels},
+				"spec":     spec,
+				"status":   status,
+			}}
+			obj.SetGroupVersionKind(SubscriptionListGVK.GroupVersion().WithKind(kind))
+			return obj
+		}
+		k8sClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithRESTMapper(testrestmapper.TestOnlyStaticRESTMapper(scheme)).
+			WithObjects(
+				object("Subscription", "operators", nil, map[string]any{"name": "cert-manager"}, map[string]any{"installedCSV": "cert-manager"}),
+				object("ClusterServiceVersion", "operators", nil, map[string]any{"version": "1.14.0"}, map[string]any{"phase": "Succeeded"}),
+				object("ClusterServiceVersion", "default", map[string]any{olmCopiedFromLabel: "operators"}, map[string]any{"version": "1.14.0"}, map[string]any{"phase": "Succeeded"}),
+			).
+			Build()
+
+		olmOperators, err := GetOLMOperators(context.Background(), log.Log, k8sClient)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(olmOperators).To(HaveLen(1))
+		Expect(olmOperators[0].Version).To(Equal("1.14.0"))
+		Expect(olmOperators[0].Phase).To(Equal("Succeeded"))
+	})
+
+	It("should read the subscription details", func() {
+		subscription := unstructured.Unstructured{Object: map[string]any{
+			"metadata": map[string]any{"name": "cert-manager", "namespace": "cert-manager-operator"},
+			"spec": map[string]any{
+				"name":                "openshift-cert-manager-operator",
+				"channel":             "stable-v1",
+				"source":              "redhat-operators",
+				"installPlanApproval": "Manual",
+			},
+			"status": map[string]any{"installedCSV": "cert-manager-operator.v1.14.0"},
+		}}
+
+		olmOperator := olmOperatorFromSubscription(subscription)
+		Expect(olmOperator.Package).To(Equal("openshift-cert-manager-operator"))
+		Expect(olmOperator.Channel).To(Equal("stable-v1"))
+		Expect(olmOperator.InstallPlanApproval).To(Equal("Manual"))
+		Expect(olmOperator.InstalledCSV).To(Equal("cert-manager-operator.v1.14.0"))
+	})
+})
@@ -0,0 +1,85 @@
+package resources
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/dana-team/axiom-operator/api/v1alpha1"
+	"github.com/dana-team/axiom-operator/internal/controller/common"
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// providerPlatforms maps the provider of node providerIDs to the platform type, for clusters without an Infrastructure.
+var providerPlatforms = map[string]configv1.PlatformType{
+	"aws":       configv1.AWSPlatformType,
+	"azure":     configv1.AzurePlatformType,
+	"gce":       configv1.GCPPlatformType,
+	"vsphere":   configv1.VSpherePlatformType,
+	"openstack": configv1.OpenStackPlatformType,
+	"ibm":       configv1.IBMCloudPlatformType,
+	"kubevirt":  configv1.KubevirtPlatformType,
+	"nutanix":   configv1.NutanixPlatformType,
+}
+
+// GetPlatform returns the platform type, topologies and region of the cluster from its Infrastructure, and the
+// zones and providers of its nodes. Without an Infrastructure, the platform type is derived from the node providers
+// and the region from the node topology labels.
+func GetPlatform(infrastructure *configv1.Infrastructure, nodes []corev1.Node) v1alpha1.Platform {
+	var platform v1alpha1.Platform
+	var regions []string
+	for _, node := range nodes {
+		if provider, _, ok := strings.Cut(node.Spec.ProviderID, "://"); ok && provider != "" {
+			platform.Providers = append(platform.Providers, provider)
+		}
+		if zone := node.Labels[corev1.LabelTopologyZone]; zone != "" {
+			platform.Zones = append(platform.Zones, zone)
+		}
+		if region := node.Labels[corev1.LabelTopologyRegion]; region != "" {
+			regions = append(regions, region)
+		}
+	}
+	platform.Providers = common.FilterUniqueStrings(platform.Providers)
+	platform.Zones = common.FilterUniqueStrings(platform.Zones)
+	regions = common.FilterUniqueStrings(regions)
+	sort.Strings(platform.Providers)
+	sort.Strings(platform.Zones)
+	sort.Strings(regions)
+	platform.Region = strings.Join(regions, ",")
+
+	if infrastructure == nil {
+		if len(platform.Providers) == 1 {
+			platform.Type = string(providerPlatforms[platform.Providers[0]])
+		}
+		return platform
+	}
+
+	platform.Type = string(infrastructure.Status.Platform)
+	platform.ControlPlaneTopology = string(infrastructure.Status.ControlPlaneTopology)
+	platform.InfrastructureTopology = string(infrastructure.Status.InfrastructureTopology)
+	if status := infrastructure.Status.PlatformStatus; status != nil {
+		platform.Type = string(status.Type)
+		if region := platformRegion(status); region != "" {
+			platform.Region = region
+		}
+	}
+	return platform
+}
+
+// platformRegion returns the region reported by the platform status of cloud platforms.
+func platformRegion(status *configv1.PlatformStatus) string {
+	switch {
+	case status.AWS != nil:
+		return status.AWS.Region
+	case status.GCP != nil:
+		return status.GCP.Region
+	case status.IBMCloud != nil:
+		return status.IBMCloud.Location
+	case status.PowerVS != nil:
+		return status.PowerVS.Region
+	case status.AlibabaCloud != nil:
+		return status.AlibabaCloud.Region
+	default:
+		return ""
+	}
+}
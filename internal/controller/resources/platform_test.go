@@ -0,0 +1,51 @@
+package resources
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Platform", func() {
+	node := func(name, providerID, zone string) corev1.Node {
+		return corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{
+				corev1.LabelTopologyRegion: "eu-west-1",
+				corev1.LabelTopologyZone:   zone,
+			}},
+			Spec: corev1.NodeSpec{ProviderID: providerID},
+		}
+	}
+	nodes := []corev1.Node{
+		node("worker-0", "aws:///eu-west-1a/i-0", "eu-west-1a"),
+		node("worker-1", "aws:///eu-west-1b/i-1", "eu-west-1b"),
+	}
+
+	It("should take the platform and topologies from the Infrastructure", func() {
+		infrastructure := &configv1.Infrastructure{Status: configv1.InfrastructureStatus{
+			Platform:               configv1.AWSPlatformType,
+			ControlPlaneTopology:   configv1.ExternalTopologyMode,
+			InfrastructureTopology: configv1.HighlyAvailableTopologyMode,
+			PlatformStatus: &configv1.PlatformStatus{
+				Type: configv1.AWSPlatformType,
+				AWS:  &configv1.AWSPlatformStatus{Region: "eu-west-1"},
+			},
+		}}
+
+		platform := GetPlatform(infrastructure, nodes)
+		Expect(platform.Type).To(Equal("AWS"))
+		Expect(platform.ControlPlaneTopology).To(Equal("External"))
+		Expect(platform.Region).To(Equal("eu-west-1"))
+		Expect(platform.Zones).To(Equal([]string{"eu-west-1a", "eu-west-1b"}))
+		Expect(platform.Providers).To(Equal([]string{"aws"}))
+	})
+
+	It("should derive the platform from the node providers without an Infrastructure", func() {
+		platform := GetPlatform(nil, nodes)
+		Expect(platform.Type).To(Equal("AWS"))
+		Expect(platform.Region).To(Equal("eu-west-1"))
+		Expect(platform.ControlPlaneTopology).To(BeEmpty())
+	})
+})
@@ -0,0 +1,110 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dana-team/axiom-operator/api/v1alpha1"
+	"github.com/dana-team/axiom-operator/internal/controller/common"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// quotaResource maps a resource of the overcommit report to its ResourceQuota names and capacity.
+type quotaResource struct {
+	name     string
+	requests []corev1.ResourceName
+	limits   []corev1.ResourceName
+	capacity string
+	format   func(*resource.Quantity) string
+}
+
+// GetQuotaOvercommit sums the cpu, memory and GPU hard limits of the ResourceQuotas of all the namespaces and
+// compares them to the capacity of the cluster, computed by CalculateClusterCompute.
+func GetQuotaOvercommit(ctx context.Context, logger logr.Logger, k8sClient client.Client, clusterResources v1alpha1.ClusterResources) (v1alpha1.QuotaOvercommit, error) {
+	quotaList := &corev1.ResourceQuotaList{}
+	if err := k8sClient.List(ctx, quotaList); err != nil {
+		logger.Error(err, "Failed to list ResourceQuotas")
+		return v1alpha1.QuotaOvercommit{}, err
+	}
+	limitRangeList := &corev1.LimitRangeList{}
+	if err := k8sClient.List(ctx, limitRangeList); err != nil {
+		logger.Error(err, "Failed to list LimitRanges")
+		return v1alpha1.QuotaOvercommit{}, err
+	}
+
+	limitRangeNamespaces := map[string]bool{}
+	for _, limitRange := range limitRangeList.Items {
+		limitRangeNamespaces[limitRange.Namespace] = true
+	}
+	overcommit := calculateQuotaOvercommit(quotaList.Items, clusterResources)
+	overcommit.LimitRangeNamespaces = len(limitRangeNamespaces)
+	return overcommit, nil
+}
+
+// calculateQuotaOvercommit sums the hard limits of the ResourceQuotas and divides them by the cluster capacity.
+// The requests of a resource are also set by the quota of its bare name, e.g. cpu for requests.cpu.
+func calculateQuotaOvercommit(quotas []corev1.ResourceQuota, clusterResources v1alpha1.ClusterResources) v1alpha1.QuotaOvercommit {
+	quotaResources := []quotaResource{
+		{
+			name:     "cpu",
+			requests: []corev1.ResourceName{corev1.ResourceRequestsCPU, corev1.ResourceCPU},
+			limits:   []corev1.ResourceName{corev1.ResourceLimitsCPU},
+			capacity: clusterResources.CPU,
+			format:   func(q *resource.Quantity) string { return q.String() },
+		},
+		{
+			name:     "memory",
+			requests: []corev1.ResourceName{corev1.ResourceRequestsMemory, corev1.ResourceMemory},
+			limits:   []corev1.ResourceName{corev1.ResourceLimitsMemory},
+			capacity: clusterResources.Memory,
+			format:   common.FormatMiB,
+		},
+		{
+			name:     "gpu",
+			requests: []corev1.ResourceName{corev1.DefaultResourceRequestsPrefix + common.GpuLabel},
+			limits:   []corev1.ResourceName{"limits." + common.GpuLabel},
+			capacity: clusterResources.GPU,
+			format:   func(q *resource.Quantity) string { return fmt.Sprintf("%d", q.Value()) },
+		},
+	}
+
+	overcommit := v1alpha1.QuotaOvercommit{ResourceQuotas: len(quotas)}
+	for _, quotaResource := range quotaResources {
+		requests := sumHardLimits(quotas, quotaResource.requests)
+		limits := sumHardLimits(quotas, quotaResource.limits)
+		resourceOvercommit := v1alpha1.ResourceOvercommit{
+			Resource: quotaResource.name,
+			Capacity: quotaResource.capacity,
+			Requests: quotaResource.format(requests),
+			Limits:   quotaResource.format(limits),
+		}
+		if capacity, err := resource.ParseQuantity(quotaResource.capacity); err == nil && !capacity.IsZero() {
+			resourceOvercommit.RequestsRatio = overcommitRatio(requests, &capacity)
+			resourceOvercommit.LimitsRatio = overcommitRatio(limits, &capacity)
+		}
+		overcommit.Resources = append(overcommit.Resources, resourceOvercommit)
+	}
+	return overcommit
+}
+
+// sumHardLimits sums the hard limits of the quotas for the first of the given names each quota sets.
+func sumHardLimits(quotas []corev1.ResourceQuota, names []corev1.ResourceName) *resource.Quantity {
+	total := resource.NewQuantity(0, resource.BinarySI)
+	for _, quota := range quotas {
+		for _, name := range names {
+			if hard, ok := quota.Spec.Hard[name]; ok {
+				total.Add(hard)
+				break
+			}
+		}
+	}
+	return total
+}
+
+// overcommitRatio formats the ratio of the quota total to the capacity with two decimals.
+func overcommitRatio(total, capacity *resource.Quantity) string {
+	return fmt.Sprintf("%.2f", total.AsApproximateFloat64()/capacity.AsApproximateFloat64())
+}
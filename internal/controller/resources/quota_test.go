@@ -0,0 +1,34 @@
+package resources
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/dana-team/axiom-operator/api/v1alpha1"
+)
+
+var _ = Describe("QuotaOvercommit", func() {
+	It("should divide the quota totals by the cluster capacity", func() {
+		quota := func(hard corev1.ResourceList) corev1.ResourceQuota {
+			return corev1.ResourceQuota{Spec: corev1.ResourceQuotaSpec{Hard: hard}}
+		}
+		quotas := []corev1.ResourceQuota{
+			quota(corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("8"), corev1.ResourceLimitsCPU: resource.MustParse("16")}),
+			quota(corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")}),
+		}
+
+		overcommit := calculateQuotaOvercommit(quotas, v1alpha1.ClusterResources{CPU: "8", Memory: "16384Mi", GPU: "0"})
+		Expect(overcommit.ResourceQuotas).To(Equal(2))
+		Expect(overcommit.Resources[0]).To(Equal(v1alpha1.ResourceOvercommit{
+			Resource:      "cpu",
+			Capacity:      "8",
+			Requests:      "12",
+			Limits:        "16",
+			RequestsRatio: "1.50",
+			LimitsRatio:   "2.00",
+		}))
+		Expect(overcommit.Resources[2].RequestsRatio).To(BeEmpty())
+	})
+})
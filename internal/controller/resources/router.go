@@ -3,54 +3,140 @@ package resources
 import (
 	"context"
 	"net"
+	"net/url"
 	"strings"
 
+	"github.com/dana-team/axiom-operator/api/v1alpha1"
 	"github.com/dana-team/axiom-operator/internal/controller/common"
 
 	"github.com/go-logr/logr"
+	configv1 "github.com/openshift/api/config/v1"
 	v1 "github.com/openshift/api/route/v1"
+	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// GetRouterLBAddress retrieves the load balancer IP addresses for the OpenShift console route
-// by looking up the DNS host of the console route
-func GetRouterLBAddress(ctx context.Context, logger logr.Logger, k8sClient client.Client) ([]string, error) {
-	route := &v1.Route{}
-	if err := k8sClient.Get(ctx, client.ObjectKey{Name: common.ConsoleName, Namespace: common.ConsoleNamespace}, route); err != nil {
-		logger.Error(err, "Failed to get console route")
-		return nil, err
+const (
+	// WildcardProbeHost is resolved under the domain of the default IngressController to find the router addresses.
+	// Any name resolves to the routers through the wildcard DNS record of the domain.
+	WildcardProbeHost = "axiom-wildcard-probe"
+
+	apiServerHostPrefix = "api."
+)
+
+// GetRouterLBAddress retrieves the addresses of the default router. These are the load balancer addresses of the
+// default IngressController when it is published through a LoadBalancer service, or else the addresses its wildcard
+// domain resolves to. Load balancer hostnames are resolved to their IPs.
+// Falls back to resolving the host of the OpenShift console route when there is no default IngressController.
+// Plain Kubernetes clusters have no router addresses.
+func GetRouterLBAddress(ctx context.Context, logger logr.Logger, k8sClient client.Client, distribution string, ingressControllers []v1alpha1.IngressController) ([]string, error) {
+	for _, ingressController := range ingressControllers {
+		if ingressController.Name != DefaultIngressControllerName || ingressController.Domain == "" {
+			continue
+		}
+		if len(ingressController.LoadBalancerAddresses) > 0 {
+			return resolveAddresses(logger, ingressController.LoadBalancerAddresses)
+		}
+		return resolveAddresses(logger, []string{WildcardProbeHost + "." + ingressController.Domain})
+	}
+	if !IsOpenShift(distribution) {
+		return nil, nil
 	}
-	ips, err := net.LookupHost(route.Spec.Host)
+
+	route, err := getConsoleRoute(ctx, logger, k8sClient)
 	if err != nil {
-		logger.Error(err, "Failed to lookup host")
 		return nil, err
 	}
-	return ips, nil
+	return resolveAddresses(logger, []string{route.Spec.Host})
 }
 
-// GetApiServerAddress retrieves the API server IP addresses by getting the console route
-// and replacing its prefix with "api." to construct the API server URL
-func GetApiServerAddress(ctx context.Context, logger logr.Logger, k8sClient client.Client) ([]string, error) {
-	route := &v1.Route{}
-	if err := k8sClient.Get(ctx, client.ObjectKey{Name: common.ConsoleName, Namespace: common.ConsoleNamespace}, route); err != nil {
-		logger.Error(err, "Failed to get console route")
+// GetApiServerAddress retrieves the API server IP addresses by resolving the host of the API server URL of the
+// cluster Infrastructure. When the Infrastructure is nil, the API server host is derived from the console route
+// by replacing its prefix with "api.". On plain Kubernetes, the host of the API server the operator connects to is used.
+func GetApiServerAddress(ctx context.Context, logger logr.Logger, k8sClient client.Client, restConfig *rest.Config, distribution string, infrastructure *configv1.Infrastructure) ([]string, error) {
+	host, err := getAPIServerHost(ctx, logger, k8sClient, restConfig, distribution, infrastructure)
+	if err != nil {
 		return nil, err
 	}
-	apiServerURL := strings.Replace(route.Spec.Host, common.IngressPrefix, "api.", 1)
-	ips, err := net.LookupHost(apiServerURL)
+	return resolveAddresses(logger, []string{host})
+}
+
+// GetClusterName returns spec.clusterName when set. Otherwise, it returns the cluster name and base domain,
+// e.g. "ocp.example.com", taken from the host of the API server URL without its "api." prefix.
+// On plain Kubernetes, the cluster name of the kubeadm configuration is preferred to the API server host.
+func GetClusterName(ctx context.Context, logger logr.Logger, k8sClient client.Client, restConfig *rest.Config, ci *v1alpha1.ClusterInfo, distribution string, infrastructure *configv1.Infrastructure) (string, error) {
+	if ci.Spec.ClusterName != "" {
+		return ci.Spec.ClusterName, nil
+	}
+	if !IsOpenShift(distribution) {
+		kubeadmConfig, err := getKubeadmClusterConfiguration(ctx, k8sClient)
+		if err != nil {
+			logger.Error(err, "Failed to get kubeadm configuration")
+			return "", err
+		}
+		if kubeadmConfig.ClusterName != "" {
+			return kubeadmConfig.ClusterName, nil
+		}
+	}
+
+	host, err := getAPIServerHost(ctx, logger, k8sClient, restConfig, distribution, infrastructure)
 	if err != nil {
-		logger.Error(err, "Failed to lookup host")
-		return nil, err
+		return "", err
+	}
+	return strings.TrimPrefix(host, apiServerHostPrefix), nil
+}
+
+// getAPIServerHost returns the host of the API server URL of the cluster Infrastructure,
+// or the API server host derived from the console route when the Infrastructure is nil or has no API server URL.
+// On plain Kubernetes, it returns the host of the API server the operator connects to, taken from restConfig.
+func getAPIServerHost(ctx context.Context, logger logr.Logger, k8sClient client.Client, restConfig *rest.Config, distribution string, infrastructure *configv1.Infrastructure) (string, error) {
+	if !IsOpenShift(distribution) {
+		return hostOf(logger, restConfig.Host)
+	}
+	if infrastructure != nil && infrastructure.Status.APIServerURL != "" {
+		return hostOf(logger, infrastructure.Status.APIServerURL)
 	}
-	return ips, nil
+
+	route, err := getConsoleRoute(ctx, logger, k8sClient)
+	if err != nil {
+		return "", err
+	}
+	return strings.Replace(route.Spec.Host, common.IngressPrefix, apiServerHostPrefix, 1), nil
 }
 
-func GetClusterName(ctx context.Context, logger logr.Logger, k8sClient client.Client) (string, error) {
+// hostOf returns the host of an API server URL, without its port.
+func hostOf(logger logr.Logger, apiServerURL string) (string, error) {
+	parsed, err := url.Parse(apiServerURL)
+	if err != nil {
+		logger.Error(err, "Failed to parse API server URL")
+		return "", err
+	}
+	return parsed.Hostname(), nil
+}
+
+func getConsoleRoute(ctx context.Context, logger logr.Logger, k8sClient client.Client) (*v1.Route, error) {
 	route := &v1.Route{}
 	if err := k8sClient.Get(ctx, client.ObjectKey{Name: common.ConsoleName, Namespace: common.ConsoleNamespace}, route); err != nil {
 		logger.Error(err, "Failed to get console route")
-		return "", err
+		return nil, err
+	}
+	return route, nil
+}
+
+// resolveAddresses returns the given IPs and the IPs the given hostnames resolve to.
+func resolveAddresses(logger logr.Logger, hosts []string) ([]string, error) {
+	var ips []string
+	for _, host := range hosts {
+		if net.ParseIP(host) != nil {
+			ips = append(ips, host)
+			continue
+		}
+		resolved, err := net.LookupHost(host)
+		if err != nil {
+			logger.Error(err, "Failed to lookup host")
+			return nil, err
+		}
+		ips = append(ips, resolved...)
 	}
-	clusterName := strings.Replace(route.Spec.Host, common.IngressPrefix, "", 1)
-	return clusterName, nil
+	return common.FilterUniqueStrings(ips), nil
 }
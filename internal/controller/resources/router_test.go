@@ -0,0 +1,92 @@
+package resources
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	configv1 "github.com/openshift/api/config/v1"
+	routev1 "github.com/openshift/api/route/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/dana-team/axiom-operator/api/v1alpha1"
+	"github.com/dana-team/axiom-operator/internal/controller/common"
+)
+
+var _ = Describe("Router", func() {
+	ci := &v1alpha1.ClusterInfo{}
+	consoleRoute := &routev1.Route{
+		ObjectMeta: metav1.ObjectMeta{Name: common.ConsoleName, Namespace: common.ConsoleNamespace},
+		Spec:       routev1.RouteSpec{Host: common.IngressPrefix + "ocp.example.com"},
+	}
+	newClient := func(objects ...client.Object) client.Client {
+		scheme := runtime.NewScheme()
+		Expect(routev1.AddToScheme(scheme)).To(Succeed())
+		return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	}
+
+	It("should take the router addresses from the load balancer of the default IngressController", func() {
+		ingressControllers := []v1alpha1.IngressController{
+			{Name: "sharded", Domain: "shard.ocp.example.com", LoadBalancerAddresses: []string{"192.0.2.20"}},
+			{Name: DefaultIngressControllerName, Domain: "apps.ocp.example.com", LoadBalancerAddresses: []string{"192.0.2.10", "192.0.2.11", "192.0.2.10"}},
+		}
+
+		addresses, err := GetRouterLBAddress(context.Background(), log.Log, newClient(), v1alpha1.DistributionOpenShift, ingressControllers)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(addresses).To(Equal([]string{"192.0.2.10", "192.0.2.11"}))
+	})
+
+	It("should have no router addresses on plain Kubernetes", func() {
+		addresses, err := GetRouterLBAddress(context.Background(), log.Log, newClient(), v1alpha1.DistributionKubernetes, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(addresses).To(BeEmpty())
+	})
+
+	It("should take the API server address and cluster name from the Infrastructure", func() {
+		infrastructure := &configv1.Infrastructure{Status: configv1.InfrastructureStatus{APIServerURL: "https://192.0.2.1:6443"}}
+
+		addresses, err := GetApiServerAddress(context.Background(), log.Log, newClient(consoleRoute), nil, v1alpha1.DistributionOpenShift, infrastructure)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(addresses).To(Equal([]string{"192.0.2.1"}))
+
+		infrastructure.Status.APIServerURL = "https://api.hub.example.com:6443"
+		clusterName, err := GetClusterName(context.Background(), log.Log, newClient(consoleRoute), nil, ci, v1alpha1.DistributionOpenShift, infrastructure)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clusterName).To(Equal("hub.example.com"))
+	})
+
+	It("should fall back to the console route without an Infrastructure API server URL", func() {
+		clusterName, err := GetClusterName(context.Background(), log.Log, newClient(consoleRoute), nil, ci, v1alpha1.DistributionOpenShift, &configv1.Infrastructure{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clusterName).To(Equal("ocp.example.com"))
+
+		_, err = GetClusterName(context.Background(), log.Log, newClient(), nil, ci, v1alpha1.DistributionOpenShift, nil)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should prefer spec.clusterName", func() {
+		ci := &v1alpha1.ClusterInfo{Spec: v1alpha1.ClusterInfoSpec{ClusterName: "prod-east"}}
+
+		clusterName, err := GetClusterName(context.Background(), log.Log, newClient(), nil, ci, v1alpha1.DistributionOpenShift, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clusterName).To(Equal("prod-east"))
+	})
+
+	It("should take the API server host from the REST config on plain Kubernetes", func() {
+		restConfig := &rest.Config{Host: "https://192.0.2.5:6443"}
+		k8sClient := fake.NewClientBuilder().Build()
+
+		addresses, err := GetApiServerAddress(context.Background(), log.Log, k8sClient, restConfig, v1alpha1.DistributionKubernetes, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(addresses).To(Equal([]string{"192.0.2.5"}))
+
+		clusterName, err := GetClusterName(context.Background(), log.Log, k8sClient, restConfig, ci, v1alpha1.DistributionKubernetes, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clusterName).To(Equal("192.0.2.5"))
+	})
+})
@@ -2,139 +2,221 @@ package resources
 
 import (
 	"context"
-	"crypto/tls"
-	"encoding/json"
 	"fmt"
-	"net/http"
+	"net"
 	"net/url"
-	"os"
+	"sort"
 
 	"github.com/dana-team/axiom-operator/api/v1alpha1"
 	"github.com/dana-team/axiom-operator/internal/controller/common"
+	"github.com/dana-team/axiom-operator/internal/netbox"
 	"github.com/go-logr/logr"
 	nmstatev1 "github.com/nmstate/kubernetes-nmstate/api/v1beta1"
 	"gopkg.in/yaml.v3"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-const ApiPrefix = "/api/ipam/prefixes/"
+const (
+	ApiPrefix = "/api/ipam/prefixes/"
+
+	// netBoxPrefixBatchSize bounds the number of prefixes looked up in a single NetBox query.
+	netBoxPrefixBatchSize = 50
+)
 
 // GetClusterSegments retrieves network segments for a cluster based on its configuration and node network state.
-// If the cluster is hosted, segments are fetched from an external NetBox API. Otherwise, segments are derived from node configurations.
+// If the cluster is hosted, segments are fetched from an external NetBox API. Otherwise, segments are derived from node
+// configurations and enriched with their NetBox metadata when NetBox is configured.
+// A nil NetBox client means NetBox is not configured.
 // Returns a list of unique segments or an error in case of failure.
-func GetClusterSegments(ctx context.Context, logger logr.Logger, k8sClient client.Client, ci *v1alpha1.ClusterInfo, nodes []corev1.Node, clusterName string) ([]string, error) {
-	if ci.Spec.HostedCluster {
-		return getSegmentsFromNetBox(ctx, logger, clusterName)
+func GetClusterSegments(ctx context.Context, logger logr.Logger, k8sClient client.Client, ci *v1alpha1.ClusterInfo, hosted bool, nb *netbox.Client, nodes []corev1.Node, clusterName string) ([]v1alpha1.Segment, error) {
+	if hosted {
+		if nb == nil {
+			return nil, nil
+		}
+		return getSegmentsFromNetBox(ctx, logger, nb, clusterName)
 	}
 
-	return getSegmentsFromNodeNetworkState(ctx, logger, k8sClient, nodes)
+	segments, err := getSegmentsFromNodeNetworkState(ctx, logger, k8sClient, nodes, includeLinkLocalSegments(ci))
+	if err != nil || nb == nil {
+		return segments, err
+	}
+
+	// NetBox metadata is best effort for segments discovered on the nodes.
+	if err := enrichSegmentsFromNetBox(ctx, nb, segments); err != nil {
+		logger.Error(err, "Failed to get segments metadata from NetBox")
+	}
+	return segments, nil
 }
 
 // getSegmentsFromNetBox retrieves unique network segments from the NetBox API for a specified cluster.
 // Returns a list of segments or an error if the retrieval or parsing of data fails.
-func getSegmentsFromNetBox(ctx context.Context, logger logr.Logger, clusterName string) ([]string, error) {
-	NetBoxURL := os.Getenv("NETBOX_URL")
-	NetBoxToken := os.Getenv("NETBOX_TOKEN")
-	if NetBoxURL == "" || NetBoxToken == "" {
-		logger.Info("NETBOX_URL or NETBOX_TOKEN environment variables are not set. Skipping NetBox segments retrieval.")
-		return nil, nil
-	}
-
-	baseURL, err := url.Parse(NetBoxURL + ApiPrefix)
+func getSegmentsFromNetBox(ctx context.Context, logger logr.Logger, nb *netbox.Client, clusterName string) ([]v1alpha1.Segment, error) {
+	results, err := netbox.List[NetBoxResult](ctx, nb, ApiPrefix, url.Values{"cf_Cluster": {clusterName}})
 	if err != nil {
-		logger.Error(err, "Failed to parse NetBox URL")
+		logger.Error(err, "Failed to get segments from NetBox")
 		return nil, err
 	}
 
-	params := url.Values{}
-	params.Add("cf_Cluster", clusterName)
-	params.Add("limit", "0")
-	baseURL.RawQuery = params.Encode()
+	logger.Info(fmt.Sprintf("Found %d prefixes for cluster %s", len(results), clusterName))
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL.String(), nil)
-	if err != nil {
-		logger.Error(err, "Failed to create NetBox request")
-		return nil, err
+	seen := map[string]struct{}{}
+	var segments []v1alpha1.Segment
+	for _, prefix := range results {
+		if _, ok := seen[prefix.Prefix]; ok {
+			continue
+		}
+		seen[prefix.Prefix] = struct{}{}
+
+		segment := v1alpha1.Segment{Prefix: prefix.Prefix, Family: common.SegmentIPFamily(prefix.Prefix)}
+		applyNetBoxMetadata(&segment, prefix)
+		segments = append(segments, segment)
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Token %s", NetBoxToken))
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Content-Type", "application/json")
+	return segments, nil
+}
 
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+// enrichSegmentsFromNetBox looks up the given segments in NetBox and copies their IPAM metadata in place.
+// Segments missing from NetBox are left untouched.
+func enrichSegmentsFromNetBox(ctx context.Context, nb *netbox.Client, segments []v1alpha1.Segment) error {
+	byPrefix := make(map[string]*v1alpha1.Segment, len(segments))
+	prefixes := make([]string, 0, len(segments))
+	for i := range segments {
+		byPrefix[segments[i].Prefix] = &segments[i]
+		prefixes = append(prefixes, segments[i].Prefix)
 	}
-	c := &http.Client{Transport: tr}
 
-	resp, err := c.Do(req)
-	if err != nil {
-		logger.Error(err, "Failed to send NetBox request")
-		return nil, err
-	}
-	defer func() {
-		err = resp.Body.Close()
+	for start := 0; start < len(prefixes); start += netBoxPrefixBatchSize {
+		batch := prefixes[start:min(start+netBoxPrefixBatchSize, len(prefixes))]
+		results, err := netbox.List[NetBoxResult](ctx, nb, ApiPrefix, url.Values{"prefix": batch})
 		if err != nil {
-			logger.Info("Failed to close NetBox response body")
+			return err
+		}
+		for _, prefix := range results {
+			if segment, ok := byPrefix[prefix.Prefix]; ok {
+				applyNetBoxMetadata(segment, prefix)
+			}
 		}
-	}()
-
-	if resp.StatusCode != http.StatusOK {
-		logger.Error(fmt.Errorf("NetBox request failed with status code %d", resp.StatusCode), "Failed to get segments from NetBox")
-		return nil, fmt.Errorf("NetBox request failed with status code %d", resp.StatusCode)
 	}
+	return nil
+}
 
-	var results NetBoxResponse
-	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
-		return nil, fmt.Errorf("failed to parse NetBox response: %w", err)
+// applyNetBoxMetadata copies the VLAN, VRF, site, role, tenant, status and description of a NetBox prefix to a segment.
+func applyNetBoxMetadata(segment *v1alpha1.Segment, prefix NetBoxResult) {
+	segment.Description = prefix.Description
+	if prefix.Status != nil {
+		segment.Status = prefix.Status.Value
 	}
-
-	logger.Info(fmt.Sprintf("Found %d prefixes for cluster %s", results.Count, clusterName))
-
-	var segments []string
-	for _, prefix := range results.Results {
-		segments = append(segments, prefix.Prefix)
+	if prefix.VLAN != nil {
+		segment.VLANID = prefix.VLAN.VID
+		segment.VLANName = prefix.VLAN.Name
+	}
+	if prefix.VRF != nil {
+		segment.VRF = prefix.VRF.Name
+	}
+	if prefix.Role != nil {
+		segment.Role = prefix.Role.Name
+	}
+	if prefix.Tenant != nil {
+		segment.Tenant = prefix.Tenant.Name
+	}
+	if prefix.Site != nil {
+		segment.Site = prefix.Site.Name
+	} else if prefix.Scope != nil && prefix.ScopeType == "dcim.site" {
+		segment.Site = prefix.Scope.Name
 	}
-
-	return common.FilterUniqueStrings(segments), nil
 }
 
 // getSegmentsFromNodeNetworkState retrieves unique network segments from the NodeNetworkState of each provided node.
-// Queries the Kubernetes API to fetch NodeNetworkState objects for the given nodes and parses associated IPv4 information.
+// Queries the Kubernetes API to fetch NodeNetworkState objects for the given nodes and parses their IPv4 and IPv6
+// addresses, skipping link-local addresses unless includeLinkLocal is set.
+// Each segment records the nodes and interfaces holding an address in it.
 // Returns a slice of unique network segments or an error if any operation fails.
-func getSegmentsFromNodeNetworkState(ctx context.Context, logger logr.Logger, k8sClient client.Client, nodes []corev1.Node) ([]string, error) {
-	var segments []string
+func getSegmentsFromNodeNetworkState(ctx context.Context, logger logr.Logger, k8sClient client.Client, nodes []corev1.Node, includeLinkLocal bool) ([]v1alpha1.Segment, error) {
+	segmentsByPrefix := map[string]*v1alpha1.Segment{}
 	for _, node := range nodes {
-		nns := &nmstatev1.NodeNetworkState{}
-		if err := k8sClient.Get(ctx, client.ObjectKey{Name: node.Name}, nns); err != nil {
-			if errors.IsNotFound(err) {
-				logger.Info(fmt.Sprintf("Node %s has no NodeNetworkState", node.Name))
-				continue
-			}
-			logger.Error(err, fmt.Sprintf("Failed to get NodeNetworkState for node %s", node.Name))
+		state, err := getNodeNetworkState(ctx, logger, k8sClient, node.Name)
+		if err != nil {
 			return nil, err
 		}
+		if state == nil {
+			continue
+		}
 
-		var state NodeNetworkStateCurrentState
-		if err := yaml.Unmarshal(nns.Status.CurrentState.Raw, &state); err != nil {
-			logger.Error(err, fmt.Sprintf("Failed to unmarshal NodeNetworkState for node %s", node.Name))
+		if err := addNodeSegments(segmentsByPrefix, node.Name, *state, includeLinkLocal); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to get segments of node %s", node.Name))
 			return nil, err
 		}
+	}
+
+	segments := make([]v1alpha1.Segment, 0, len(segmentsByPrefix))
+	for _, segment := range segmentsByPrefix {
+		segment.Nodes = common.FilterUniqueStrings(segment.Nodes)
+		segment.Interfaces = common.FilterUniqueStrings(segment.Interfaces)
+		sort.Strings(segment.Nodes)
+		sort.Strings(segment.Interfaces)
+		segments = append(segments, *segment)
+	}
+	sort.Slice(segments, func(i, j int) bool {
+		return segments[i].Prefix < segments[j].Prefix
+	})
+
+	return segments, nil
+}
+
+// getNodeNetworkState fetches and decodes the current network state of a node from its NodeNetworkState.
+// Returns nil without an error when the node has no NodeNetworkState.
+func getNodeNetworkState(ctx context.Context, logger logr.Logger, k8sClient client.Client, nodeName string) (*NodeNetworkStateCurrentState, error) {
+	nns := &nmstatev1.NodeNetworkState{}
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: nodeName}, nns); err != nil {
+		if apierrors.IsNotFound(err) || common.IsAPINotAvailable(err) {
+			logger.Info(fmt.Sprintf("Node %s has no NodeNetworkState", nodeName))
+			return nil, nil
+		}
+		logger.Error(err, fmt.Sprintf("Failed to get NodeNetworkState for node %s", nodeName))
+		return nil, err
+	}
 
-		for _, iface := range state.Interfaces {
-			for _, segment := range iface.Ipv4.Address {
-				if segment.IP != "" {
-					s, err := common.CreateSegmentFromIPAndPrefix(segment.IP, segment.PrefixLength)
-					if err != nil {
-						logger.Error(err, fmt.Sprintf("Failed to create segment from IP %s and prefix %d", segment.IP, segment.PrefixLength))
-						return nil, err
-					}
-					segments = append(segments, s)
-				}
+	state := &NodeNetworkStateCurrentState{}
+	if err := yaml.Unmarshal(nns.Status.CurrentState.Raw, state); err != nil {
+		logger.Error(err, fmt.Sprintf("Failed to unmarshal NodeNetworkState for node %s", nodeName))
+		return nil, err
+	}
+	return state, nil
+}
+
+// addNodeSegments adds the segments of the IPv4 and IPv6 addresses in the network state of a node to segmentsByPrefix.
+func addNodeSegments(segmentsByPrefix map[string]*v1alpha1.Segment, nodeName string, state NodeNetworkStateCurrentState, includeLinkLocal bool) error {
+	for _, iface := range state.Interfaces {
+		addresses := append(append([]Address{}, iface.Ipv4.Address...), iface.Ipv6.Address...)
+		for _, address := range addresses {
+			if address.IP == "" {
+				continue
+			}
+			if ip := net.ParseIP(address.IP); ip != nil && ip.IsLinkLocalUnicast() && !includeLinkLocal {
+				continue
+			}
+			prefix, err := common.CreateSegmentFromIPAndPrefix(address.IP, address.PrefixLength)
+			if err != nil {
+				return err
+			}
+
+			segment, ok := segmentsByPrefix[prefix]
+			if !ok {
+				segment = &v1alpha1.Segment{Prefix: prefix, Family: common.SegmentIPFamily(prefix)}
+				segmentsByPrefix[prefix] = segment
+			}
+			segment.Nodes = append(segment.Nodes, nodeName)
+			if iface.Name != "" {
+				segment.Interfaces = append(segment.Interfaces, iface.Name)
 			}
 		}
 	}
+	return nil
+}
 
-	return common.FilterUniqueStrings(segments), nil
+// includeLinkLocalSegments reports whether link-local segments are requested by the ClusterInfo.
+func includeLinkLocalSegments(ci *v1alpha1.ClusterInfo) bool {
+	return ci.Spec.Segments != nil && ci.Spec.Segments.IncludeLinkLocal
 }
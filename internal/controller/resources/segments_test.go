@@ -0,0 +1,178 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/dana-team/axiom-operator/api/v1alpha1"
+	"github.com/dana-team/axiom-operator/internal/netbox"
+	"github.com/dana-team/axiom-operator/internal/netbox/netboxtest"
+)
+
+var _ = Describe("Node segments", func() {
+	const currentState = `
+interfaces:
+- name: br-ex
+  ipv4:
+    address:
+    - ip: 10.0.0.10
+      prefix-length: 24
+    - ip: 169.254.169.2
+      prefix-length: 29
+  ipv6:
+    address:
+    - ip: fd00:10::10
+      prefix-length: 64
+    - ip: fe80::1
+      prefix-length: 64
+`
+
+	var state NodeNetworkStateCurrentState
+
+	BeforeEach(func() {
+		Expect(yaml.Unmarshal([]byte(currentState), &state)).To(Succeed())
+	})
+
+	It("should compute IPv4 and IPv6 segments and skip link-local addresses", func() {
+		segments := map[string]*v1alpha1.Segment{}
+		Expect(addNodeSegments(segments, "master-0", state, false)).To(Succeed())
+
+		Expect(segments).To(HaveLen(2))
+		Expect(segments).To(HaveKeyWithValue("10.0.0.0/24", HaveField("Family", corev1.IPv4Protocol)))
+		Expect(segments).To(HaveKeyWithValue("fd00:10::/64", HaveField("Family", corev1.IPv6Protocol)))
+		Expect(segments["fd00:10::/64"].Interfaces).To(Equal([]string{"br-ex"}))
+	})
+
+	It("should include link-local segments when requested", func() {
+		segments := map[string]*v1alpha1.Segment{}
+		Expect(addNodeSegments(segments, "master-0", state, true)).To(Succeed())
+
+		Expect(segments).To(HaveKey("169.254.169.0/29"))
+		Expect(segments).To(HaveKey("fe80::/64"))
+	})
+})
+
+var _ = Describe("NetBox segment metadata", func() {
+	var (
+		ctx    context.Context
+		server *netboxtest.Server
+		nb     *netbox.Client
+	)
+
+	// prefixLookups returns the number of prefixes of each prefix lookup sent to NetBox.
+	prefixLookups := func() []int {
+		var out []int
+		for _, request := range server.Requests() {
+			if !strings.HasPrefix(request, "GET "+ApiPrefix+"?") {
+				continue
+			}
+			parsed, err := url.ParseRequestURI(strings.TrimPrefix(request, "GET "))
+			Expect(err).NotTo(HaveOccurred())
+			out = append(out, len(parsed.Query()["prefix"]))
+		}
+		return out
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		server = netboxtest.NewServer("token")
+		DeferCleanup(server.Close)
+
+		var err error
+		nb, err = netbox.NewClient(netbox.Config{
+			URL:               server.URL,
+			Token:             "token",
+			CABundle:          server.CABundle(),
+			RequestsPerSecond: 1000,
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should look up the node segments in batches of 50 prefixes", func() {
+		segments := make([]v1alpha1.Segment, 120)
+		for i := range segments {
+			segments[i] = v1alpha1.Segment{Prefix: fmt.Sprintf("10.0.%d.0/24", i)}
+		}
+		server.Add(ApiPrefix, map[string]any{"prefix": "10.0.0.0/24", "description": "first batch"})
+		server.Add(ApiPrefix, map[string]any{"prefix": "10.0.119.0/24", "description": "last batch"})
+
+		Expect(enrichSegmentsFromNetBox(ctx, nb, segments)).To(Succeed())
+		Expect(prefixLookups()).To(Equal([]int{50, 50, 20}))
+		Expect(segments[0].Description).To(Equal("first batch"))
+		Expect(segments[119].Description).To(Equal("last batch"))
+	})
+
+	It("should copy the IPAM metadata and leave the prefixes missing from NetBox untouched", func() {
+		segments := []v1alpha1.Segment{
+			{Prefix: "10.0.0.0/24", Nodes: []string{"master-0"}},
+			{Prefix: "10.0.1.0/24"},
+			{Prefix: "10.0.2.0/24", Description: "not in NetBox"},
+		}
+		server.Add(ApiPrefix, map[string]any{
+			"prefix":      "10.0.0.0/24",
+			"description": "machine network",
+			"status":      map[string]any{"value": "active", "label": "Active"},
+			"vlan":        map[string]any{"id": 7, "vid": 100, "name": "ocp-machines"},
+			"vrf":         map[string]any{"id": 3, "name": "prod"},
+			"role":        map[string]any{"id": 4, "name": "kubernetes"},
+			"tenant":      map[string]any{"id": 5, "name": "platform"},
+			"site":        map[string]any{"id": 6, "name": "dc-east"},
+		})
+		server.Add(ApiPrefix, map[string]any{"prefix": "10.0.9.0/24", "description": "not on the nodes"})
+
+		Expect(enrichSegmentsFromNetBox(ctx, nb, segments)).To(Succeed())
+		Expect(segments).To(Equal([]v1alpha1.Segment{
+			{
+				Prefix:      "10.0.0.0/24",
+				Nodes:       []string{"master-0"},
+				Description: "machine network",
+				Status:      "active",
+				VLANID:      100,
+				VLANName:    "ocp-machines",
+				VRF:         "prod",
+				Role:        "kubernetes",
+				Tenant:      "platform",
+				Site:        "dc-east",
+			},
+			{Prefix: "10.0.1.0/24"},
+			{Prefix: "10.0.2.0/24", Description: "not in NetBox"},
+		}))
+	})
+
+	It("should read the site from the scope of NetBox 4.2 and later", func() {
+		server.Add(ApiPrefix, map[string]any{
+			"prefix":        "10.0.0.0/24",
+			"scope_type":    "dcim.site",
+			"scope":         map[string]any{"id": 6, "name": "dc-east"},
+			"custom_fields": map[string]any{"Cluster": "ocp.example.com"},
+		})
+		server.Add(ApiPrefix, map[string]any{
+			"prefix":        "10.0.1.0/24",
+			"scope_type":    "dcim.region",
+			"scope":         map[string]any{"id": 2, "name": "east"},
+			"custom_fields": map[string]any{"Cluster": "ocp.example.com"},
+		})
+		server.Add(ApiPrefix, map[string]any{
+			"prefix":        "10.0.2.0/24",
+			"site":          map[string]any{"id": 8, "name": "dc-west"},
+			"custom_fields": map[string]any{"Cluster": "ocp.example.com"},
+		})
+		server.Add(ApiPrefix, map[string]any{"prefix": "10.0.3.0/24", "custom_fields": map[string]any{"Cluster": "other"}})
+
+		segments, err := getSegmentsFromNetBox(ctx, log.Log, nb, "ocp.example.com")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(segments).To(HaveLen(3))
+		Expect(segments[0]).To(HaveField("Site", "dc-east"))
+		Expect(segments[0]).To(HaveField("Family", corev1.IPv4Protocol))
+		Expect(segments[1]).To(HaveField("Site", ""))
+		Expect(segments[2]).To(HaveField("Site", "dc-west"))
+	})
+})
@@ -2,29 +2,137 @@ package resources
 
 import (
 	"context"
+	"strings"
 
 	"github.com/dana-team/axiom-operator/api/v1alpha1"
+	"github.com/dana-team/axiom-operator/internal/controller/common"
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const (
+	DefaultStorageClassAnnotation     = "storageclass.kubernetes.io/is-default-class"
+	BetaDefaultStorageClassAnnotation = "storageclass.beta.kubernetes.io/is-default-class"
+
+	// RedactedParameterValue replaces the value of StorageClass parameters holding credentials.
+	RedactedParameterValue = "<redacted>"
+
+	// csiParameterPrefix prefixes the parameters reserved to the CSI external provisioner. They reference Secrets
+	// by name and never hold credentials.
+	csiParameterPrefix = "csi.storage.k8s.io/"
+)
+
+// credentialParameterKeywords are the keywords that mark a StorageClass parameter as holding credentials.
+var credentialParameterKeywords = []string{"secret", "password", "passwd", "token", "credential", "apikey", "accesskey"}
+
 // GetStorageProvisioners retrieves a list of storage provisioners from the cluster by listing all StorageClasses
 // and extracting their provisioner information. This information is used to determine available storage options
-// in the cluster.
+// in the cluster. Each provisioner is enriched with the capabilities of its CSIDriver and the total capacity of the
+// PersistentVolumes of its StorageClass.
 func GetStorageProvisioners(ctx context.Context, logger logr.Logger, k8sClient client.Client) ([]v1alpha1.StorageProvisioner, error) {
 	scList := &storagev1.StorageClassList{}
 	if err := k8sClient.List(ctx, scList); err != nil {
 		logger.Error(err, "Failed to list StorageClasses")
 		return nil, err
 	}
+	csiDriverList := &storagev1.CSIDriverList{}
+	if err := k8sClient.List(ctx, csiDriverList); err != nil {
+		logger.Error(err, "Failed to list CSIDrivers")
+		return nil, err
+	}
+	pvList := &corev1.PersistentVolumeList{}
+	if err := k8sClient.List(ctx, pvList); err != nil {
+		logger.Error(err, "Failed to list PersistentVolumes")
+		return nil, err
+	}
+
+	csiDrivers := make(map[string]storagev1.CSIDriver, len(csiDriverList.Items))
+	for _, csiDriver := range csiDriverList.Items {
+		csiDrivers[csiDriver.Name] = csiDriver
+	}
+	capacities := map[string]*resource.Quantity{}
+	for _, pv := range pvList.Items {
+		capacity, ok := pv.Spec.Capacity[corev1.ResourceStorage]
+		if !ok || pv.Spec.StorageClassName == "" {
+			continue
+		}
+		if _, ok := capacities[pv.Spec.StorageClassName]; !ok {
+			capacities[pv.Spec.StorageClassName] = resource.NewQuantity(0, resource.BinarySI)
+		}
+		capacities[pv.Spec.StorageClassName].Add(capacity)
+	}
+
 	provisioners := []v1alpha1.StorageProvisioner{}
 	for _, sc := range scList.Items {
 		sp := v1alpha1.StorageProvisioner{
-			Name:        sc.Name,
-			Provisioner: sc.Provisioner,
+			Name:                 sc.Name,
+			Provisioner:          sc.Provisioner,
+			Default:              isDefaultStorageClass(sc),
+			AllowVolumeExpansion: ptr.Deref(sc.AllowVolumeExpansion, false),
+			Parameters:           redactParameters(sc.Parameters),
+		}
+		if sc.ReclaimPolicy != nil {
+			sp.ReclaimPolicy = string(*sc.ReclaimPolicy)
+		}
+		if sc.VolumeBindingMode != nil {
+			sp.VolumeBindingMode = string(*sc.VolumeBindingMode)
+		}
+		if csiDriver, ok := csiDrivers[sc.Provisioner]; ok {
+			sp.CSIDriver = csiDriverCapabilities(csiDriver)
+		}
+		if capacity, ok := capacities[sc.Name]; ok {
+			sp.ProvisionedCapacity = common.FormatMiB(capacity)
 		}
 		provisioners = append(provisioners, sp)
 	}
 	return provisioners, nil
 }
+
+// isDefaultStorageClass tells whether the StorageClass is annotated as the default one.
+func isDefaultStorageClass(sc storagev1.StorageClass) bool {
+	return sc.Annotations[DefaultStorageClassAnnotation] == "true" || sc.Annotations[BetaDefaultStorageClassAnnotation] == "true"
+}
+
+// redactParameters returns a copy of the StorageClass parameters in which the values of the parameters holding
+// credentials are replaced with RedactedParameterValue.
+func redactParameters(parameters map[string]string) map[string]string {
+	if len(parameters) == 0 {
+		return nil
+	}
+	redacted := make(map[string]string, len(parameters))
+	for key, value := range parameters {
+		redacted[key] = value
+		if strings.HasPrefix(key, csiParameterPrefix) {
+			continue
+		}
+		normalizedKey := strings.NewReplacer("-", "", "_", "").Replace(strings.ToLower(key))
+		for _, keyword := range credentialParameterKeywords {
+			if strings.Contains(normalizedKey, keyword) {
+				redacted[key] = RedactedParameterValue
+				break
+			}
+		}
+	}
+	return redacted
+}
+
+// csiDriverCapabilities returns the capabilities declared by a CSIDriver.
+func csiDriverCapabilities(csiDriver storagev1.CSIDriver) *v1alpha1.CSIDriver {
+	capabilities := &v1alpha1.CSIDriver{
+		AttachRequired:    ptr.Deref(csiDriver.Spec.AttachRequired, true),
+		PodInfoOnMount:    ptr.Deref(csiDriver.Spec.PodInfoOnMount, false),
+		StorageCapacity:   ptr.Deref(csiDriver.Spec.StorageCapacity, false),
+		RequiresRepublish: ptr.Deref(csiDriver.Spec.RequiresRepublish, false),
+	}
+	if csiDriver.Spec.FSGroupPolicy != nil {
+		capabilities.FSGroupPolicy = string(*csiDriver.Spec.FSGroupPolicy)
+	}
+	for _, mode := range csiDriver.Spec.VolumeLifecycleModes {
+		capabilities.VolumeLifecycleModes = append(capabilities.VolumeLifecycleModes, string(mode))
+	}
+	return capabilities
+}
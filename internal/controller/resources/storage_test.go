@@ -0,0 +1,54 @@
+package resources
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("StorageProvisioners", func() {
+	It("should redact the credentials in StorageClass parameters", func() {
+		parameters := redactParameters(map[string]string{
+			"pool":                      "replicapool",
+			"adminPassword":             "s3cr3t",
+			"access_key_id":             "AKIA0000",
+			"csi.storage.k8s.io/fstype": "ext4",
+			"csi.storage.k8s.io/provisioner-secret-name": "rook-csi-rbd-provisioner",
+		})
+		Expect(parameters).To(Equal(map[string]string{
+			"pool":                      "replicapool",
+			"adminPassword":             RedactedParameterValue,
+			"access_key_id":             RedactedParameterValue,
+			"csi.storage.k8s.io/fstype": "ext4",
+			"csi.storage.k8s.io/provisioner-secret-name": "rook-csi-rbd-provisioner",
+		}))
+	})
+	It("should report the provisioned capacity in MiB", func() {
+		pv := func(name, capacity string) *corev1.PersistentVolume {
+			return &corev1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec: corev1.PersistentVolumeSpec{
+					StorageClassName: "standard",
+					Capacity:         corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(capacity)},
+				},
+			}
+		}
+		k8sClient := fake.NewClientBuilder().WithObjects(
+			&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "standard"}, Provisioner: "kubernetes.io/no-provisioner"},
+			pv("pv-0", "1Gi"),
+			pv("pv-1", "512Mi"),
+		).Build()
+
+		provisioners, err := GetStorageProvisioners(context.Background(), log.Log, k8sClient)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(provisioners).To(HaveLen(1))
+		Expect(provisioners[0].ProvisionedCapacity).To(Equal("1536Mi"))
+	})
+})
@@ -0,0 +1,87 @@
+package resources
+
+import (
+	"context"
+	"sort"
+
+	"github.com/dana-team/axiom-operator/api/v1alpha1"
+	"github.com/dana-team/axiom-operator/internal/controller/common"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetStorageUsage aggregates the PersistentVolumes and PersistentVolumeClaims of the cluster by StorageClass and
+// phase, and counts the Released PersistentVolumes left behind by deleted claims.
+func GetStorageUsage(ctx context.Context, logger logr.Logger, k8sClient client.Client) (v1alpha1.StorageUsage, error) {
+	pvList := &corev1.PersistentVolumeList{}
+	if err := k8sClient.List(ctx, pvList); err != nil {
+		logger.Error(err, "Failed to list PersistentVolumes")
+		return v1alpha1.StorageUsage{}, err
+	}
+	pvcList := &corev1.PersistentVolumeClaimList{}
+	if err := k8sClient.List(ctx, pvcList); err != nil {
+		logger.Error(err, "Failed to list PersistentVolumeClaims")
+		return v1alpha1.StorageUsage{}, err
+	}
+	return aggregateStorageUsage(pvList.Items, pvcList.Items), nil
+}
+
+// storageClassTotals holds the running totals of a StorageClass.
+type storageClassTotals struct {
+	usage     v1alpha1.StorageClassUsage
+	capacity  resource.Quantity
+	requested resource.Quantity
+}
+
+// aggregateStorageUsage aggregates the given PersistentVolumes and PersistentVolumeClaims.
+func aggregateStorageUsage(pvs []corev1.PersistentVolume, pvcs []corev1.PersistentVolumeClaim) v1alpha1.StorageUsage {
+	totals := map[string]*storageClassTotals{}
+	totalsOf := func(storageClass string) *storageClassTotals {
+		if _, ok := totals[storageClass]; !ok {
+			totals[storageClass] = &storageClassTotals{
+				usage: v1alpha1.StorageClassUsage{StorageClass: storageClass, Volumes: map[string]int{}, Claims: map[string]int{}},
+			}
+		}
+		return totals[storageClass]
+	}
+
+	var usage v1alpha1.StorageUsage
+	var capacity, requested, orphanedCapacity resource.Quantity
+	for _, pv := range pvs {
+		classTotals := totalsOf(pv.Spec.StorageClassName)
+		classTotals.usage.Volumes[string(pv.Status.Phase)]++
+		pvCapacity := pv.Spec.Capacity[corev1.ResourceStorage]
+		classTotals.capacity.Add(pvCapacity)
+		capacity.Add(pvCapacity)
+		if pv.Status.Phase == corev1.VolumeReleased {
+			usage.OrphanedVolumes++
+			orphanedCapacity.Add(pvCapacity)
+		}
+	}
+	for _, pvc := range pvcs {
+		var storageClass string
+		if pvc.Spec.StorageClassName != nil {
+			storageClass = *pvc.Spec.StorageClassName
+		}
+		classTotals := totalsOf(storageClass)
+		classTotals.usage.Claims[string(pvc.Status.Phase)]++
+		pvcRequest := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+		classTotals.requested.Add(pvcRequest)
+		requested.Add(pvcRequest)
+	}
+
+	for _, classTotals := range totals {
+		classTotals.usage.Capacity = common.FormatMiB(&classTotals.capacity)
+		classTotals.usage.Requested = common.FormatMiB(&classTotals.requested)
+		usage.StorageClasses = append(usage.StorageClasses, classTotals.usage)
+	}
+	sort.Slice(usage.StorageClasses, func(i, j int) bool {
+		return usage.StorageClasses[i].StorageClass < usage.StorageClasses[j].StorageClass
+	})
+	usage.Capacity = common.FormatMiB(&capacity)
+	usage.Requested = common.FormatMiB(&requested)
+	usage.OrphanedCapacity = common.FormatMiB(&orphanedCapacity)
+	return usage
+}
@@ -0,0 +1,46 @@
+package resources
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+)
+
+var _ = Describe("StorageUsage", func() {
+	pv := func(storageClass, capacity string, phase corev1.PersistentVolumePhase) corev1.PersistentVolume {
+		return corev1.PersistentVolume{
+			Spec: corev1.PersistentVolumeSpec{
+				StorageClassName: storageClass,
+				Capacity:         corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(capacity)},
+			},
+			Status: corev1.PersistentVolumeStatus{Phase: phase},
+		}
+	}
+
+	It("should aggregate volumes and claims by storage class and count orphaned volumes", func() {
+		pvs := []corev1.PersistentVolume{
+			pv("fast", "10Gi", corev1.VolumeBound),
+			pv("fast", "5Gi", corev1.VolumeReleased),
+		}
+		pvcs := []corev1.PersistentVolumeClaim{{
+			Spec: corev1.PersistentVolumeClaimSpec{
+				StorageClassName: ptr.To("fast"),
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("8Gi")},
+				},
+			},
+			Status: corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimBound},
+		}}
+
+		usage := aggregateStorageUsage(pvs, pvcs)
+		Expect(usage.OrphanedVolumes).To(Equal(1))
+		Expect(usage.OrphanedCapacity).To(Equal("5120Mi"))
+		Expect(usage.StorageClasses).To(HaveLen(1))
+		Expect(usage.StorageClasses[0].Volumes).To(Equal(map[string]int{"Bound": 1, "Released": 1}))
+		Expect(usage.StorageClasses[0].Claims).To(Equal(map[string]int{"Bound": 1}))
+		Expect(usage.StorageClasses[0].Capacity).To(Equal("15360Mi"))
+		Expect(usage.StorageClasses[0].Requested).To(Equal("8192Mi"))
+	})
+})
@@ -2,13 +2,47 @@ package resources
 
 type NodeNetworkStateCurrentState struct {
 	Interfaces []Interface `json:"interfaces,omitempty" yaml:"interfaces,omitempty"`
+	Routes     Routes      `json:"routes,omitempty" yaml:"routes,omitempty"`
 }
 
 type Interface struct {
-	Ipv4 Ipv4 `json:"ipv4,omitempty" yaml:"ipv4,omitempty"`
+	Name            string           `json:"name,omitempty" yaml:"name,omitempty"`
+	Type            string           `json:"type,omitempty" yaml:"type,omitempty"`
+	State           string           `json:"state,omitempty" yaml:"state,omitempty"`
+	MTU             int              `json:"mtu,omitempty" yaml:"mtu,omitempty"`
+	MACAddress      string           `json:"mac-address,omitempty" yaml:"mac-address,omitempty"`
+	LinkAggregation *LinkAggregation `json:"link-aggregation,omitempty" yaml:"link-aggregation,omitempty"`
+	VLAN            *VLAN            `json:"vlan,omitempty" yaml:"vlan,omitempty"`
+	Ipv4            IPConfig         `json:"ipv4,omitempty" yaml:"ipv4,omitempty"`
+	Ipv6            IPConfig         `json:"ipv6,omitempty" yaml:"ipv6,omitempty"`
 }
 
-type Ipv4 struct {
+// LinkAggregation is the bond configuration of an interface. Older nmstate versions report the bond ports as slaves.
+type LinkAggregation struct {
+	Mode   string   `json:"mode,omitempty" yaml:"mode,omitempty"`
+	Port   []string `json:"port,omitempty" yaml:"port,omitempty"`
+	Slaves []string `json:"slaves,omitempty" yaml:"slaves,omitempty"`
+}
+
+type VLAN struct {
+	BaseIface string `json:"base-iface,omitempty" yaml:"base-iface,omitempty"`
+	ID        int    `json:"id,omitempty" yaml:"id,omitempty"`
+}
+
+type Routes struct {
+	Running []Route `json:"running,omitempty" yaml:"running,omitempty"`
+}
+
+type Route struct {
+	Destination      string `json:"destination,omitempty" yaml:"destination,omitempty"`
+	NextHopAddress   string `json:"next-hop-address,omitempty" yaml:"next-hop-address,omitempty"`
+	NextHopInterface string `json:"next-hop-interface,omitempty" yaml:"next-hop-interface,omitempty"`
+	Metric           int    `json:"metric,omitempty" yaml:"metric,omitempty"`
+	TableID          int    `json:"table-id,omitempty" yaml:"table-id,omitempty"`
+}
+
+// IPConfig is the IPv4 or IPv6 configuration of an interface.
+type IPConfig struct {
 	Address []Address `json:"address,omitempty" yaml:"address,omitempty" `
 }
 
@@ -17,15 +51,54 @@ type Address struct {
 	PrefixLength int    `json:"prefix-length,omitempty" yaml:"prefix-length,omitempty"`
 }
 
-type NetBoxResponse struct {
-	Count   int            `json:"count,omitempty"`
-	Results []NetBoxResult `json:"results,omitempty"`
+type NetBoxResult struct {
+	ID          int                 `json:"id,omitempty"`
+	Prefix      string              `json:"prefix,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Status      *NetBoxChoice       `json:"status,omitempty"`
+	VLAN        *NetBoxVLAN         `json:"vlan,omitempty"`
+	VRF         *NetBoxNestedObject `json:"vrf,omitempty"`
+	Role        *NetBoxNestedObject `json:"role,omitempty"`
+	Tenant      *NetBoxNestedObject `json:"tenant,omitempty"`
+	// Site is set by NetBox versions prior to 4.2, newer versions assign prefixes to a Scope.
+	Site         *NetBoxNestedObject `json:"site,omitempty"`
+	ScopeType    string              `json:"scope_type,omitempty"`
+	Scope        *NetBoxNestedObject `json:"scope,omitempty"`
+	CustomFields CustomFields        `json:"custom_fields,omitempty"`
+}
+
+type NetBoxNestedObject struct {
+	ID   int    `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+	Slug string `json:"slug,omitempty"`
 }
 
-type NetBoxResult struct {
-	ID           int          `json:"id,omitempty"`
-	Prefix       string       `json:"prefix,omitempty"`
-	CustomFields CustomFields `json:"custom_fields,omitempty"`
+type NetBoxVLAN struct {
+	ID   int    `json:"id,omitempty"`
+	VID  int    `json:"vid,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+type NetBoxChoice struct {
+	Value string `json:"value,omitempty"`
+	Label string `json:"label,omitempty"`
+}
+
+// NetBoxHost is a NetBox device or virtual machine.
+type NetBoxHost struct {
+	ID         int                 `json:"id,omitempty"`
+	Name       string              `json:"name,omitempty"`
+	Cluster    *NetBoxNestedObject `json:"cluster,omitempty"`
+	PrimaryIP4 *NetBoxNestedObject `json:"primary_ip4,omitempty"`
+	PrimaryIP6 *NetBoxNestedObject `json:"primary_ip6,omitempty"`
+}
+
+type NetBoxIPAddress struct {
+	ID                 int                 `json:"id,omitempty"`
+	Address            string              `json:"address,omitempty"`
+	AssignedObjectType string              `json:"assigned_object_type,omitempty"`
+	AssignedObjectID   int                 `json:"assigned_object_id,omitempty"`
+	VRF                *NetBoxNestedObject `json:"vrf,omitempty"`
 }
 
 type CustomFields struct {
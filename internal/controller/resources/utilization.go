@@ -0,0 +1,112 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/dana-team/axiom-operator/api/v1alpha1"
+	"github.com/dana-team/axiom-operator/internal/controller/common"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NodeMetricsListGVK is the kind of the metrics.k8s.io node metrics list, read as an unstructured object.
+var NodeMetricsListGVK = schema.GroupVersionKind{Group: "metrics.k8s.io", Version: "v1beta1", Kind: "NodeMetricsList"}
+
+// utilizationResources are the resources whose utilization is reported, with their format.
+var utilizationResources = []struct {
+	name   corev1.ResourceName
+	format func(*resource.Quantity) string
+}{
+	{name: corev1.ResourceCPU, format: func(q *resource.Quantity) string { return q.String() }},
+	{name: corev1.ResourceMemory, format: common.FormatMiB},
+}
+
+// GetResourceUtilization reports, for cpu and memory, the capacity and allocatable of the nodes, the requests and
+// limits of the pods scheduled on them and their current usage from metrics.k8s.io. The usage is left empty when
+// the metrics API is not installed or not available.
+func GetResourceUtilization(ctx context.Context, logger logr.Logger, k8sClient client.Client, nodes []corev1.Node, pods []corev1.Pod) ([]v1alpha1.ResourceUtilization, error) {
+	usage := getNodesUsage(ctx, logger, k8sClient)
+
+	var utilization []v1alpha1.ResourceUtilization
+	for _, r := range utilizationResources {
+		capacity := resource.NewQuantity(0, resource.BinarySI)
+		allocatable := resource.NewQuantity(0, resource.BinarySI)
+		for _, node := range nodes {
+			capacity.Add(node.Status.Capacity[r.name])
+			allocatable.Add(node.Status.Allocatable[r.name])
+		}
+		requested := resource.NewQuantity(0, resource.BinarySI)
+		limits := resource.NewQuantity(0, resource.BinarySI)
+		for _, pod := range pods {
+			if pod.Spec.NodeName == "" || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+				continue
+			}
+			requested.Add(podResource(pod, r.name, func(c corev1.Container) corev1.ResourceList { return c.Resources.Requests }))
+			limits.Add(podResource(pod, r.name, func(c corev1.Container) corev1.ResourceList { return c.Resources.Limits }))
+		}
+
+		resourceUtilization := v1alpha1.ResourceUtilization{
+			Resource:    string(r.name),
+			Capacity:    r.format(capacity),
+			Allocatable: r.format(allocatable),
+			Requested:   r.format(requested),
+			Limits:      r.format(limits),
+		}
+		if used, ok := usage[r.name]; ok {
+			resourceUtilization.Used = r.format(&used)
+		}
+		utilization = append(utilization, resourceUtilization)
+	}
+	return utilization, nil
+}
+
+// podResource returns the amount of a resource a pod requests or is limited to, as the scheduler accounts it: the
+// larger of the sum over its containers and the largest init container, plus the pod overhead.
+func podResource(pod corev1.Pod, name corev1.ResourceName, resourcesOf func(corev1.Container) corev1.ResourceList) resource.Quantity {
+	var total resource.Quantity
+	for _, container := range pod.Spec.Containers {
+		total.Add(resourcesOf(container)[name])
+	}
+	for _, container := range pod.Spec.InitContainers {
+		if quantity := resourcesOf(container)[name]; quantity.Cmp(total) > 0 {
+			total = quantity.DeepCopy()
+		}
+	}
+	total.Add(pod.Spec.Overhead[name])
+	return total
+}
+
+// getNodesUsage sums the usage of the nodes reported by metrics.k8s.io. It returns nil when the metrics API is
+// not available.
+func getNodesUsage(ctx context.Context, logger logr.Logger, k8sClient client.Client) corev1.ResourceList {
+	nodeMetricsList := &unstructured.UnstructuredList{}
+	nodeMetricsList.SetGroupVersionKind(NodeMetricsListGVK)
+	if err := k8sClient.List(ctx, nodeMetricsList); err != nil {
+		if common.IsAPINotAvailable(err) || apierrors.IsServiceUnavailable(err) || apierrors.IsNotFound(err) {
+			logger.Info("Metrics API is not available, skipping resource usage")
+		} else {
+			logger.Error(err, "Failed to list NodeMetrics, skipping resource usage")
+		}
+		return nil
+	}
+
+	usage := corev1.ResourceList{}
+	for _, nodeMetrics := range nodeMetricsList.Items {
+		nodeUsage, _, _ := unstructured.NestedStringMap(nodeMetrics.Object, "usage")
+		for _, r := range utilizationResources {
+			quantity, err := resource.ParseQuantity(nodeUsage[string(r.name)])
+			if err != nil {
+				continue
+			}
+			total := usage[r.name]
+			total.Add(quantity)
+			usage[r.name] = total
+		}
+	}
+	return usage
+}
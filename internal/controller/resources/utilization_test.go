@@ -0,0 +1,52 @@
+package resources
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta/testrestmapper"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("ResourceUtilization", func() {
+	It("should sum the pod requests and skip the usage without the metrics API", func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		container := func(cpu string) corev1.Container {
+			return corev1.Container{Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+			}}
+		}
+		pod := corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+			Spec: corev1.PodSpec{
+				NodeName:       "worker-0",
+				InitContainers: []corev1.Container{container("2")},
+				Containers:     []corev1.Container{container("500m"), container("250m")},
+			},
+		}
+		k8sClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithRESTMapper(testrestmapper.TestOnlyStaticRESTMapper(scheme)).
+			Build()
+		nodes := []corev1.Node{{Status: corev1.NodeStatus{
+			Capacity:    corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("8")},
+			Allocatable: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("7500m")},
+		}}}
+
+		utilization, err := GetResourceUtilization(context.Background(), log.Log, k8sClient, nodes, []corev1.Pod{pod})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(utilization[0].Resource).To(Equal("cpu"))
+		Expect(utilization[0].Capacity).To(Equal("8"))
+		Expect(utilization[0].Allocatable).To(Equal("7500m"))
+		Expect(utilization[0].Requested).To(Equal("2"))
+		Expect(utilization[0].Used).To(BeEmpty())
+	})
+})
@@ -2,21 +2,101 @@ package resources
 
 import (
 	"context"
+	"sort"
 
+	"github.com/dana-team/axiom-operator/api/v1alpha1"
 	"github.com/go-logr/logr"
 	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/discovery"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // GetClusterVersionAndID queries the ClusterVersion resource to obtain cluster information.
-// It retrieves the desired OpenShift version from Status and cluster ID from Spec fields.
-func GetClusterVersionAndID(ctx context.Context, logger logr.Logger, k8sClient client.Client) (string, string, error) {
+// It retrieves the desired OpenShift version from Status and cluster ID from Spec fields, and returns the
+// ClusterVersion for GetClusterVersion. On plain Kubernetes, the version is the server version read through
+// discoveryClient, the cluster ID is the UID of the kube-system namespace and the returned ClusterVersion is nil.
+func GetClusterVersionAndID(ctx context.Context, logger logr.Logger, k8sClient client.Client, discoveryClient discovery.ServerVersionInterface, distribution string) (string, string, *configv1.ClusterVersion, error) {
+	if !IsOpenShift(distribution) {
+		return getKubernetesVersionAndID(ctx, logger, k8sClient, discoveryClient)
+	}
+
 	cv := &configv1.ClusterVersion{}
 	if err := k8sClient.Get(ctx, client.ObjectKey{Name: "version"}, cv); err != nil {
 		logger.Error(err, "Failed to get ClusterVersion")
-		return "", "", err
+		return "", "", nil, err
 	}
 	ocpVersion := cv.Status.Desired.Version
 	clusterID := cv.Spec.ClusterID
-	return ocpVersion, string(clusterID), nil
+	return ocpVersion, string(clusterID), cv, nil
+}
+
+func getKubernetesVersionAndID(ctx context.Context, logger logr.Logger, k8sClient client.Client, discoveryClient discovery.ServerVersionInterface) (string, string, *configv1.ClusterVersion, error) {
+	namespace := &corev1.Namespace{}
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: KubeSystemNamespace}, namespace); err != nil {
+		logger.Error(err, "Failed to get kube-system namespace")
+		return "", "", nil, err
+	}
+
+	serverVersion, err := discoveryClient.ServerVersion()
+	if err != nil {
+		logger.Error(err, "Failed to get server version")
+		return "", "", nil, err
+	}
+	return serverVersion.GitVersion, string(namespace.UID), nil, nil
+}
+
+// clusterVersionConditionTypes are the ClusterVersion conditions recorded in the status.
+var clusterVersionConditionTypes = map[configv1.ClusterStatusConditionType]struct{}{
+	configv1.OperatorAvailable:                     {},
+	configv1.OperatorProgressing:                   {},
+	configv1.ClusterStatusConditionType("Failing"): {},
+	configv1.OperatorUpgradeable:                   {},
+}
+
+// GetClusterVersion returns the desired version and release image, update channel, available updates, update history
+// and main conditions of the ClusterVersion returned by GetClusterVersionAndID. Returns nil on plain Kubernetes,
+// where there is no ClusterVersion.
+func GetClusterVersion(cv *configv1.ClusterVersion) *v1alpha1.ClusterVersionInfo {
+	if cv == nil {
+		return nil
+	}
+
+	info := &v1alpha1.ClusterVersionInfo{
+		Version: cv.Status.Desired.Version,
+		Image:   cv.Status.Desired.Image,
+		Channel: cv.Spec.Channel,
+	}
+	for _, update := range cv.Status.AvailableUpdates {
+		info.AvailableUpdates = append(info.AvailableUpdates, update.Version)
+	}
+	for _, entry := range cv.Status.History {
+		info.History = append(info.History, v1alpha1.ClusterVersionUpdate{
+			Version:        entry.Version,
+			State:          string(entry.State),
+			StartedTime:    entry.StartedTime,
+			CompletionTime: entry.CompletionTime,
+			Image:          entry.Image,
+		})
+	}
+	for _, condition := range cv.Status.Conditions {
+		if _, ok := clusterVersionConditionTypes[condition.Type]; ok {
+			info.Conditions = append(info.Conditions, operatorCondition(condition))
+		}
+	}
+	sort.Slice(info.Conditions, func(i, j int) bool {
+		return info.Conditions[i].Type < info.Conditions[j].Type
+	})
+	return info
+}
+
+// operatorCondition converts a condition of a ClusterVersion or ClusterOperator.
+func operatorCondition(condition configv1.ClusterOperatorStatusCondition) v1alpha1.OperatorCondition {
+	return v1alpha1.OperatorCondition{
+		Type:               string(condition.Type),
+		Status:             string(condition.Status),
+		Reason:             condition.Reason,
+		Message:            condition.Message,
+		LastTransitionTime: condition.LastTransitionTime,
+	}
 }
@@ -0,0 +1,88 @@
+package resources
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/dana-team/axiom-operator/api/v1alpha1"
+)
+
+var _ = Describe("ClusterVersion", func() {
+	It("should read the server version and the kube-system UID on plain Kubernetes", func() {
+		k8sClient := fake.NewClientBuilder().WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: KubeSystemNamespace, UID: "0c6f1c4e"}},
+		).Build()
+		discoveryClient := &fakediscovery.FakeDiscovery{
+			Fake:               &clienttesting.Fake{},
+			FakedServerVersion: &version.Info{GitVersion: "v1.31.2"},
+		}
+
+		k8sVersion, clusterID, cv, err := GetClusterVersionAndID(context.Background(), log.Log, k8sClient, discoveryClient, v1alpha1.DistributionKubernetes)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(k8sVersion).To(Equal("v1.31.2"))
+		Expect(clusterID).To(Equal("0c6f1c4e"))
+		Expect(cv).To(BeNil())
+		Expect(GetClusterVersion(cv)).To(BeNil())
+	})
+
+	It("should read the version, channel, updates, history and conditions of the OpenShift ClusterVersion", func() {
+		scheme := runtime.NewScheme()
+		Expect(configv1.AddToScheme(scheme)).To(Succeed())
+		started := metav1.NewTime(time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC))
+		completed := metav1.NewTime(time.Date(2026, 3, 1, 11, 0, 0, 0, time.UTC))
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&configv1.ClusterVersion{
+			ObjectMeta: metav1.ObjectMeta{Name: "version"},
+			Spec:       configv1.ClusterVersionSpec{ClusterID: "5a1d3c1e", Channel: "stable-4.16"},
+			Status: configv1.ClusterVersionStatus{
+				Desired:          configv1.Release{Version: "4.16.8", Image: "quay.io/openshift-release-dev/ocp-release@sha256:16"},
+				AvailableUpdates: []configv1.Release{{Version: "4.16.9"}, {Version: "4.16.10"}},
+				History: []configv1.UpdateHistory{
+					{Version: "4.16.8", State: configv1.PartialUpdate, StartedTime: completed, Image: "quay.io/openshift-release-dev/ocp-release@sha256:16"},
+					{Version: "4.15.20", State: configv1.CompletedUpdate, StartedTime: started, CompletionTime: &completed},
+				},
+				Conditions: []configv1.ClusterOperatorStatusCondition{
+					{Type: configv1.OperatorProgressing, Status: configv1.ConditionTrue, Message: "Working towards 4.16.8"},
+					{Type: configv1.OperatorAvailable, Status: configv1.ConditionTrue},
+					{Type: "RetrievedUpdates", Status: configv1.ConditionTrue},
+				},
+			},
+		}).Build()
+
+		ocpVersion, clusterID, cv, err := GetClusterVersionAndID(context.Background(), log.Log, k8sClient, nil, v1alpha1.DistributionOpenShift)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ocpVersion).To(Equal("4.16.8"))
+		Expect(clusterID).To(Equal("5a1d3c1e"))
+
+		info := GetClusterVersion(cv)
+		Expect(info).NotTo(BeNil())
+		Expect(info.Version).To(Equal("4.16.8"))
+		Expect(info.Image).To(Equal("quay.io/openshift-release-dev/ocp-release@sha256:16"))
+		Expect(info.Channel).To(Equal("stable-4.16"))
+		Expect(info.AvailableUpdates).To(Equal([]string{"4.16.9", "4.16.10"}))
+		Expect(info.History).To(HaveLen(2))
+		Expect(info.History[0].Version).To(Equal("4.16.8"))
+		Expect(info.History[0].State).To(Equal("Partial"))
+		Expect(info.History[0].Image).To(Equal("quay.io/openshift-release-dev/ocp-release@sha256:16"))
+		Expect(info.History[0].CompletionTime).To(BeNil())
+		Expect(info.History[1].Version).To(Equal("4.15.20"))
+		Expect(info.History[1].State).To(Equal("Completed"))
+		Expect(info.History[1].StartedTime.Time).To(BeTemporally("==", started.Time))
+		Expect(info.History[1].CompletionTime.Time).To(BeTemporally("==", completed.Time))
+		Expect(info.Conditions).To(HaveLen(2))
+		Expect(info.Conditions[0].Type).To(Equal("Available"))
+		Expect(info.Conditions[1].Type).To(Equal("Progressing"))
+		Expect(info.Conditions[1].Message).To(Equal("Working towards 4.16.8"))
+	})
+})
@@ -2,9 +2,16 @@ package resources
 
 import (
 	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dana-team/axiom-operator/api/v1alpha1"
 
 	"github.com/go-logr/logr"
 	addmissionv1 "k8s.io/api/admissionregistration/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -35,3 +42,121 @@ func GetValidatingWebhooks(ctx context.Context, logger logr.Logger, k8sClient cl
 	}
 	return vWebhooks, nil
 }
+
+// GetAdmissionWebhooks retrieves the webhooks of all the MutatingWebhookConfigurations and
+// ValidatingWebhookConfigurations, with their failure policy, rules and selectors, and whether the service backing
+// each webhook has ready endpoints. The EndpointSlices of the webhook services are read through apiReader, so that
+// the EndpointSlices of the whole cluster are not cached.
+func GetAdmissionWebhooks(ctx context.Context, logger logr.Logger, k8sClient client.Client, apiReader client.Reader) ([]v1alpha1.AdmissionWebhook, error) {
+	mutatingWebhooks := &addmissionv1.MutatingWebhookConfigurationList{}
+	if err := k8sClient.List(ctx, mutatingWebhooks); err != nil {
+		logger.Error(err, "Failed to list MutatingWebhookConfiguration")
+		return nil, err
+	}
+	validatingWebhooks := &addmissionv1.ValidatingWebhookConfigurationList{}
+	if err := k8sClient.List(ctx, validatingWebhooks); err != nil {
+		logger.Error(err, "Failed to list ValidatingWebhookConfiguration")
+		return nil, err
+	}
+
+	var webhooks []v1alpha1.AdmissionWebhook
+	for _, configuration := range mutatingWebhooks.Items {
+		for _, wh := range configuration.Webhooks {
+			webhook := admissionWebhook(configuration.Name, v1alpha1.WebhookTypeMutating, wh.Name, wh.ClientConfig, wh.Rules,
+				wh.FailurePolicy, wh.SideEffects, wh.TimeoutSeconds, wh.NamespaceSelector, wh.ObjectSelector)
+			webhooks = append(webhooks, webhook)
+		}
+	}
+	for _, configuration := range validatingWebhooks.Items {
+		for _, wh := range configuration.Webhooks {
+			webhook := admissionWebhook(configuration.Name, v1alpha1.WebhookTypeValidating, wh.Name, wh.ClientConfig, wh.Rules,
+				wh.FailurePolicy, wh.SideEffects, wh.TimeoutSeconds, wh.NamespaceSelector, wh.ObjectSelector)
+			webhooks = append(webhooks, webhook)
+		}
+	}
+
+	serviceReady := map[string]*bool{}
+	for i, webhook := range webhooks {
+		if webhook.Service == "" {
+			continue
+		}
+		if _, ok := serviceReady[webhook.Service]; !ok {
+			ready, err := hasReadyEndpoints(ctx, apiReader, webhook.Service)
+			if err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to check the endpoints of service %s", webhook.Service))
+				return nil, err
+			}
+			serviceReady[webhook.Service] = &ready
+		}
+		webhooks[i].ServiceReady = serviceReady[webhook.Service]
+		if webhook.FailurePolicy == string(addmissionv1.Fail) && !*webhooks[i].ServiceReady {
+			logger.Info(fmt.Sprintf("Webhook %s has failure policy Fail and its service %s has no ready endpoints", webhook.Name, webhook.Service))
+		}
+	}
+	return webhooks, nil
+}
+
+// admissionWebhook returns the details of a webhook, common to mutating and validating webhooks.
+func admissionWebhook(configuration, webhookType, name string, clientConfig addmissionv1.WebhookClientConfig,
+	rules []addmissionv1.RuleWithOperations, failurePolicy *addmissionv1.FailurePolicyType, sideEffects *addmissionv1.SideEffectClass,
+	timeoutSeconds *int32, namespaceSelector, objectSelector *metav1.LabelSelector) v1alpha1.AdmissionWebhook {
+	webhook := v1alpha1.AdmissionWebhook{
+		Name:              name,
+		Configuration:     configuration,
+		Type:              webhookType,
+		FailurePolicy:     string(ptr.Deref(failurePolicy, "")),
+		SideEffects:       string(ptr.Deref(sideEffects, "")),
+		TimeoutSeconds:    ptr.Deref(timeoutSeconds, 0),
+		NamespaceSelector: formatLabelSelector(namespaceSelector),
+		ObjectSelector:    formatLabelSelector(objectSelector),
+		URL:               ptr.Deref(clientConfig.URL, ""),
+	}
+	if clientConfig.Service != nil {
+		webhook.Service = fmt.Sprintf("%s/%s", clientConfig.Service.Namespace, clientConfig.Service.Name)
+	}
+	for _, rule := range rules {
+		webhook.Rules = append(webhook.Rules, v1alpha1.WebhookRule{
+			Operations:  operationStrings(rule.Operations),
+			APIGroups:   rule.APIGroups,
+			APIVersions: rule.APIVersions,
+			Resources:   rule.Resources,
+			Scope:       string(ptr.Deref(rule.Scope, "")),
+		})
+	}
+	return webhook
+}
+
+// formatLabelSelector returns the string form of a label selector, or an empty string when it selects everything.
+func formatLabelSelector(selector *metav1.LabelSelector) string {
+	if selector == nil || (len(selector.MatchLabels) == 0 && len(selector.MatchExpressions) == 0) {
+		return ""
+	}
+	return metav1.FormatLabelSelector(selector)
+}
+
+func operationStrings(operations []addmissionv1.OperationType) []string {
+	result := make([]string, 0, len(operations))
+	for _, operation := range operations {
+		result = append(result, string(operation))
+	}
+	return result
+}
+
+// hasReadyEndpoints tells whether the service, given as namespace/name, has at least one ready endpoint in its
+// EndpointSlices.
+func hasReadyEndpoints(ctx context.Context, apiReader client.Reader, service string) (bool, error) {
+	namespace, name, _ := strings.Cut(service, "/")
+	endpointSlices := &discoveryv1.EndpointSliceList{}
+	if err := apiReader.List(ctx, endpointSlices, client.InNamespace(namespace),
+		client.MatchingLabels{discoveryv1.LabelServiceName: name}); err != nil {
+		return false, err
+	}
+	for _, endpointSlice := range endpointSlices.Items {
+		for _, endpoint := range endpointSlice.Endpoints {
+			if ptr.Deref(endpoint.Conditions.Ready, true) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,58 @@
+package resources
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admissionregistration/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("AdmissionWebhooks", func() {
+	It("should report a Fail webhook whose service has no ready endpoints", func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		configuration := &admissionv1.ValidatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "policy"},
+			Webhooks: []admissionv1.ValidatingWebhook{{
+				Name:          "validate.policy.example.com",
+				FailurePolicy: ptr.To(admissionv1.Fail),
+				ClientConfig: admissionv1.WebhookClientConfig{
+					Service: &admissionv1.ServiceReference{Namespace: "policy", Name: "webhook"},
+				},
+				Rules: []admissionv1.RuleWithOperations{{
+					Operations: []admissionv1.OperationType{admissionv1.Create},
+					Rule:       admissionv1.Rule{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"pods"}},
+				}},
+			}},
+		}
+		endpointSlice := &discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "webhook-abcde",
+				Namespace: "policy",
+				Labels:    map[string]string{discoveryv1.LabelServiceName: "webhook"},
+			},
+			AddressType: discoveryv1.AddressTypeIPv4,
+			Endpoints: []discoveryv1.Endpoint{{
+				Addresses:  []string{"10.128.0.10"},
+				Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(false)},
+			}},
+		}
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(configuration, endpointSlice).Build()
+
+		webhooks, err := GetAdmissionWebhooks(context.Background(), log.Log, k8sClient, k8sClient)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(webhooks).To(HaveLen(1))
+		Expect(webhooks[0].FailurePolicy).To(Equal("Fail"))
+		Expect(webhooks[0].Service).To(Equal("policy/webhook"))
+		Expect(webhooks[0].Rules[0].Operations).To(Equal([]string{"CREATE"}))
+		Expect(webhooks[0].ServiceReady).To(Equal(ptr.To(false)))
+	})
+})
@@ -0,0 +1,114 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+
+	"github.com/dana-team/axiom-operator/api/v1alpha1"
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultSystemNamespacePatterns are the glob patterns of the system namespaces when
+// spec.workloads.systemNamespacePatterns is not set.
+var DefaultSystemNamespacePatterns = []string{"openshift-*", "kube-*"}
+
+// GetWorkloads counts the namespaces, Deployments, StatefulSets and pods by phase of the cluster, leaving out the
+// system namespaces, and summarizes them per owner when spec.workloads.ownerLabel is set. Only the metadata of the
+// Deployments and StatefulSets is cached, and the pods are the ones listed by GetPods.
+func GetWorkloads(ctx context.Context, logger logr.Logger, k8sClient client.Client, ci *v1alpha1.ClusterInfo, pods []corev1.Pod) (v1alpha1.Workloads, error) {
+	patterns := DefaultSystemNamespacePatterns
+	var ownerLabel string
+	if spec := ci.Spec.Workloads; spec != nil {
+		if len(spec.SystemNamespacePatterns) > 0 {
+			patterns = spec.SystemNamespacePatterns
+		}
+		ownerLabel = spec.OwnerLabel
+	}
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			logger.Info(fmt.Sprintf("Ignoring invalid system namespace pattern %q: %v", pattern, err))
+		}
+	}
+
+	namespaceList := &corev1.NamespaceList{}
+	if err := k8sClient.List(ctx, namespaceList); err != nil {
+		logger.Error(err, "Failed to list Namespaces")
+		return v1alpha1.Workloads{}, err
+	}
+	deploymentList := &metav1.PartialObjectMetadataList{}
+	deploymentList.SetGroupVersionKind(appsv1.SchemeGroupVersion.WithKind("DeploymentList"))
+	if err := k8sClient.List(ctx, deploymentList); err != nil {
+		logger.Error(err, "Failed to list Deployments")
+		return v1alpha1.Workloads{}, err
+	}
+	statefulSetList := &metav1.PartialObjectMetadataList{}
+	statefulSetList.SetGroupVersionKind(appsv1.SchemeGroupVersion.WithKind("StatefulSetList"))
+	if err := k8sClient.List(ctx, statefulSetList); err != nil {
+		logger.Error(err, "Failed to list StatefulSets")
+		return v1alpha1.Workloads{}, err
+	}
+
+	workloads := v1alpha1.Workloads{WorkloadCounts: v1alpha1.WorkloadCounts{Pods: map[string]int{}}}
+	owners := map[string]*v1alpha1.WorkloadCounts{}
+	// ownerCounts maps the tenant namespaces to the counts of their owner.
+	ownerCounts := map[string]*v1alpha1.WorkloadCounts{}
+	for _, namespace := range namespaceList.Items {
+		if isSystemNamespace(namespace.Name, patterns) {
+			workloads.SystemNamespaces++
+			continue
+		}
+		workloads.Namespaces++
+		if ownerLabel == "" {
+			continue
+		}
+		owner := namespace.Labels[ownerLabel]
+		if _, ok := owners[owner]; !ok {
+			owners[owner] = &v1alpha1.WorkloadCounts{Pods: map[string]int{}}
+		}
+		owners[owner].Namespaces++
+		ownerCounts[namespace.Name] = owners[owner]
+	}
+
+	countIn := func(namespace string, count func(*v1alpha1.WorkloadCounts)) {
+		if isSystemNamespace(namespace, patterns) {
+			return
+		}
+		count(&workloads.WorkloadCounts)
+		if counts, ok := ownerCounts[namespace]; ok {
+			count(counts)
+		}
+	}
+	for _, deployment := range deploymentList.Items {
+		countIn(deployment.Namespace, func(counts *v1alpha1.WorkloadCounts) { counts.Deployments++ })
+	}
+	for _, statefulSet := range statefulSetList.Items {
+		countIn(statefulSet.Namespace, func(counts *v1alpha1.WorkloadCounts) { counts.StatefulSets++ })
+	}
+	for _, pod := range pods {
+		countIn(pod.Namespace, func(counts *v1alpha1.WorkloadCounts) { counts.Pods[string(pod.Status.Phase)]++ })
+	}
+
+	for owner, counts := range owners {
+		workloads.Owners = append(workloads.Owners, v1alpha1.OwnerWorkloads{Owner: owner, WorkloadCounts: *counts})
+	}
+	sort.Slice(workloads.Owners, func(i, j int) bool {
+		return workloads.Owners[i].Owner < workloads.Owners[j].Owner
+	})
+	return workloads, nil
+}
+
+// isSystemNamespace tells whether the namespace matches one of the system namespace patterns.
+func isSystemNamespace(namespace string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, namespace); matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+package resources
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/dana-team/axiom-operator/api/v1alpha1"
+)
+
+var _ = Describe("Workloads", func() {
+	It("should summarize the tenant workloads per owner", func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments", Labels: map[string]string{"team": "fintech"}}},
+			&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "payments"}},
+			&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "coredns", Namespace: "kube-system"}},
+		).Build()
+		pods := []corev1.Pod{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "api-0", Namespace: "payments"},
+				Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "coredns-0", Namespace: "kube-system"},
+				Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+			},
+		}
+		ci := &v1alpha1.ClusterInfo{Spec: v1alpha1.ClusterInfoSpec{Workloads: &v1alpha1.WorkloadsSpec{OwnerLabel: "team"}}}
+
+		workloads, err := GetWorkloads(context.Background(), log.Log, k8sClient, ci, pods)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(workloads.SystemNamespaces).To(Equal(1))
+		Expect(workloads.Namespaces).To(Equal(1))
+		Expect(workloads.Deployments).To(Equal(1))
+		Expect(workloads.Pods).To(Equal(map[string]int{"Running": 1}))
+		Expect(workloads.Owners).To(HaveLen(1))
+		Expect(workloads.Owners[0].Owner).To(Equal("fintech"))
+		Expect(workloads.Owners[0].Deployments).To(Equal(1))
+	})
+})
@@ -0,0 +1,19 @@
+package status
+
+import (
+	"github.com/dana-team/axiom-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// setCondition adds or updates a condition on the status. The transition time is only
+// changed when the condition status changes, so an unchanged condition does not trigger a status update.
+func setCondition(status *v1alpha1.ClusterInfoStatus, generation int64, conditionType string, conditionStatus metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             conditionStatus,
+		ObservedGeneration: generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
@@ -2,24 +2,51 @@ package status
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"reflect"
 
 	"github.com/dana-team/axiom-operator/api/v1alpha1"
 	"github.com/dana-team/axiom-operator/internal/controller/common"
 	"github.com/dana-team/axiom-operator/internal/controller/resources"
+	"github.com/dana-team/axiom-operator/internal/netbox"
 	"github.com/go-logr/logr"
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// Clients are the clients the cluster information is collected with. They are created once and kept across
+// reconciles, together with the clients of the external systems.
+type Clients struct {
+	// Client reads the cached objects and updates the status.
+	Client client.Client
+	// APIReader reads the objects that are not cached, such as Secrets, from the API server.
+	APIReader client.Reader
+	// RESTConfig is the configuration of the connection to the API server.
+	RESTConfig *rest.Config
+	// Discovery reads the server version of plain Kubernetes clusters.
+	Discovery discovery.ServerVersionInterface
+	// NetBoxClients keeps the NetBox clients across reconciles.
+	NetBoxClients *resources.NetBoxClientCache
+	// ManagementClients keeps the management cluster clients of hosted clusters across reconciles.
+	ManagementClients *resources.ManagementClientCache
+}
+
 // UpdateClusterInfoStatus updates the status of a ClusterInfo resource by collecting and comparing
 // the latest cluster information with the existing status. If there are differences, it updates
-// the status field of the ClusterInfo resource.
-func UpdateClusterInfoStatus(ctx context.Context, logger logr.Logger, clusterInfo v1alpha1.ClusterInfo, k8sClient client.Client) error {
-	updatedStatus, err := collectClusterInfo(ctx, logger, k8sClient, &clusterInfo)
+// the status field of the ClusterInfo resource. Returns the ClusterInfo with its updated status.
+func UpdateClusterInfoStatus(ctx context.Context, logger logr.Logger, clusterInfo v1alpha1.ClusterInfo, clients Clients) (v1alpha1.ClusterInfo, error) {
+	updatedStatus, err := collectClusterInfo(ctx, logger, clients, &clusterInfo)
 	if err != nil {
-		return err
+		return clusterInfo, err
 	}
-	return common.RetryOnConflictUpdate(ctx, &clusterInfo, k8sClient, clusterInfo.Name, clusterInfo.Namespace, func(obj *v1alpha1.ClusterInfo) error {
+	k8sClient := clients.Client
+	err = common.RetryOnConflictUpdate(ctx, &clusterInfo, k8sClient, clusterInfo.Name, clusterInfo.Namespace, func(obj *v1alpha1.ClusterInfo) error {
 		desiredCopy := updatedStatus.DeepCopy()
 		existingCopy := obj.DeepCopy()
 		desiredCopy.Normalize()
@@ -31,43 +58,126 @@ func UpdateClusterInfoStatus(ctx context.Context, logger logr.Logger, clusterInf
 		}
 		return nil
 	})
+	return clusterInfo, err
 }
 
 // collectClusterInfo gathers various information about the cluster.
-func collectClusterInfo(ctx context.Context, logger logr.Logger, k8sClient client.Client, ci *v1alpha1.ClusterInfo) (v1alpha1.ClusterInfoStatus, error) {
+func collectClusterInfo(ctx context.Context, logger logr.Logger, clients Clients, ci *v1alpha1.ClusterInfo) (v1alpha1.ClusterInfoStatus, error) {
+	k8sClient, apiReader := clients.Client, clients.APIReader
 	clusterInfo := v1alpha1.ClusterInfoStatus{}
+	for _, condition := range ci.Status.Conditions {
+		clusterInfo.Conditions = append(clusterInfo.Conditions, *condition.DeepCopy())
+	}
+
 	nodes, err := resources.GetClusterNodes(ctx, logger, k8sClient)
 	if err != nil {
 		return clusterInfo, err
 	}
+
+	infrastructure, err := resources.GetInfrastructure(ctx, logger, k8sClient)
+	if err != nil {
+		return clusterInfo, err
+	}
+
+	distribution, err := resources.GetDistribution(ctx, logger, k8sClient)
+	if err != nil {
+		return clusterInfo, err
+	}
+	clusterInfo.Distribution = distribution
+
+	clusterInfo.DetectedMode = detectClusterMode(logger, ci, distribution, infrastructure, nodes)
+	hosted := resources.IsHostedCluster(ci, clusterInfo.DetectedMode)
+
 	nodeInfo := resources.FormatNodesInfo(nodes)
 	clusterResources := resources.CalculateClusterCompute(nodes)
-	k8sVersion, clusterID, err := resources.GetClusterVersionAndID(ctx, logger, k8sClient)
+	k8sVersion, clusterID, cv, err := resources.GetClusterVersionAndID(ctx, logger, k8sClient, clients.Discovery, distribution)
+	if err != nil {
+		return clusterInfo, err
+	}
+	clusterVersion := resources.GetClusterVersion(cv)
+
+	clusterOperators, platformHealthy, err := resources.GetClusterOperators(ctx, logger, k8sClient, distribution)
+	if err != nil {
+		return clusterInfo, err
+	}
+
+	olmOperators, err := resources.GetOLMOperators(ctx, logger, apiReader)
+	if err != nil {
+		return clusterInfo, err
+	}
+
+	machineConfigPools, err := resources.GetMachineConfigPools(ctx, logger, k8sClient, distribution)
+	if err != nil {
+		return clusterInfo, err
+	}
+
+	machineSets, err := resources.GetMachineSets(ctx, logger, k8sClient, distribution)
+	if err != nil {
+		return clusterInfo, err
+	}
+
+	pods, err := resources.GetPods(ctx, logger, apiReader)
+	if err != nil {
+		return clusterInfo, err
+	}
+
+	utilization, err := resources.GetResourceUtilization(ctx, logger, k8sClient, nodes, pods)
+	if err != nil {
+		return clusterInfo, err
+	}
+	clusterResources.Utilization = utilization
+
+	quotaOvercommit, err := resources.GetQuotaOvercommit(ctx, logger, k8sClient, clusterResources)
+	if err != nil {
+		return clusterInfo, err
+	}
+
+	workloads, err := resources.GetWorkloads(ctx, logger, k8sClient, ci, pods)
+	if err != nil {
+		return clusterInfo, err
+	}
+
+	storageUsage, err := resources.GetStorageUsage(ctx, logger, k8sClient)
 	if err != nil {
 		return clusterInfo, err
 	}
 
-	clusterDnsConfig, err := resources.GetClusterDnsConfiguration(ctx, logger, k8sClient, ci)
+	admissionWebhooks, err := resources.GetAdmissionWebhooks(ctx, logger, k8sClient, apiReader)
 	if err != nil {
 		return clusterInfo, err
 	}
 
-	routerLBAddresses, err := resources.GetRouterLBAddress(ctx, logger, k8sClient)
+	crds, err := resources.GetCustomResourceDefinitions(ctx, logger, apiReader)
 	if err != nil {
 		return clusterInfo, err
 	}
 
-	apiServerAddresses, err := resources.GetApiServerAddress(ctx, logger, k8sClient)
+	apiServices, err := resources.GetAPIServices(ctx, logger, apiReader)
 	if err != nil {
 		return clusterInfo, err
 	}
 
-	clusterName, err := resources.GetClusterName(ctx, logger, k8sClient)
+	clusterDnsConfig, err := resources.GetClusterDnsConfiguration(ctx, logger, k8sClient, distribution, hosted)
 	if err != nil {
 		return clusterInfo, err
 	}
 
-	identityProviders, err := resources.GetIdentityProviders(ctx, logger, k8sClient, ci)
+	ingressControllers, err := resources.GetIngressControllers(ctx, logger, k8sClient)
+	if err != nil {
+		return clusterInfo, err
+	}
+
+	routerLBAddresses, err := resources.GetRouterLBAddress(ctx, logger, k8sClient, distribution, ingressControllers)
+	if err != nil {
+		return clusterInfo, err
+	}
+
+	apiServerAddresses, err := resources.GetApiServerAddress(ctx, logger, k8sClient, clients.RESTConfig, distribution, infrastructure)
+	if err != nil {
+		return clusterInfo, err
+	}
+
+	clusterName, err := resources.GetClusterName(ctx, logger, k8sClient, clients.RESTConfig, ci, distribution, infrastructure)
 	if err != nil {
 		return clusterInfo, err
 	}
@@ -87,11 +197,25 @@ func collectClusterInfo(ctx context.Context, logger logr.Logger, k8sClient clien
 		return clusterInfo, err
 	}
 
-	segments, err := resources.GetClusterSegments(ctx, logger, k8sClient, ci, nodes, clusterName)
+	clusterNetwork, err := resources.GetClusterNetwork(ctx, logger, k8sClient, nodes)
 	if err != nil {
 		return clusterInfo, err
 	}
 
+	networkInterfaces, err := resources.GetNetworkInterfaces(ctx, logger, k8sClient, nodes)
+	if err != nil {
+		return clusterInfo, err
+	}
+
+	nb := collectNetBoxClient(ctx, logger, apiReader, clients.NetBoxClients, ci, &clusterInfo)
+
+	segments, err := resources.GetClusterSegments(ctx, logger, k8sClient, ci, hosted, nb, nodes, clusterName)
+	if err != nil {
+		return clusterInfo, err
+	}
+
+	clusterInfo.SegmentDrift = collectSegmentDrift(ctx, logger, k8sClient, ci, hosted, &clusterInfo, nb, nodes, segments, clusterName)
+
 	clusterInfo.ClusterID = clusterID
 	clusterInfo.Name = clusterName
 	clusterInfo.KubernetesVersion = k8sVersion
@@ -100,10 +224,141 @@ func collectClusterInfo(ctx context.Context, logger logr.Logger, k8sClient clien
 	clusterInfo.ClusterResources = clusterResources
 	clusterInfo.RouterLBAddresses = routerLBAddresses
 	clusterInfo.ApiServerAddresses = apiServerAddresses
-	clusterInfo.IdentityProviders = identityProviders
+	clusterInfo.IngressControllers = ingressControllers
+	clusterInfo.ClusterVersion = clusterVersion
+	clusterInfo.ClusterOperators = clusterOperators
+	clusterInfo.PlatformHealthy = platformHealthy
+	platform := resources.GetPlatform(infrastructure, nodes)
+	clusterInfo.Platform = &platform
+	if infrastructure != nil {
+		clusterInfo.APIServerURL = infrastructure.Status.APIServerURL
+		clusterInfo.APIServerInternalURL = infrastructure.Status.APIServerInternalURL
+		clusterInfo.InfrastructureName = infrastructure.Status.InfrastructureName
+	}
+	clusterInfo.IdentityProviders = collectIdentityProviders(ctx, logger, k8sClient, apiReader, clients.ManagementClients, ci, distribution, hosted, &clusterInfo)
 	clusterInfo.StorageProvisioners = storageProvisioners
+	clusterInfo.StorageUsage = &storageUsage
+	clusterInfo.Workloads = &workloads
+	clusterInfo.MachineConfigPools = machineConfigPools
+	clusterInfo.MachineSets = machineSets
+	clusterInfo.QuotaOvercommit = &quotaOvercommit
 	clusterInfo.MutatingWebhooks = mutatingWebhooks
 	clusterInfo.ValidatingWebhooks = validatingWebhooks
+	clusterInfo.AdmissionWebhooks = admissionWebhooks
 	clusterInfo.Segments = segments
+	clusterInfo.NetworkInterfaces = networkInterfaces
+	clusterInfo.OLMOperators = olmOperators
+	clusterInfo.CustomResourceDefinitions = crds
+	clusterInfo.APIServices = apiServices
+	clusterInfo.APIChanges = resources.DetectAPIChanges(ci.Status, crds, apiServices, metav1.Now())
+	clusterInfo.ClusterNetwork = &clusterNetwork
+	clusterInfo.NetBoxSync = collectNetBoxSync(ctx, logger, ci, &clusterInfo, nb)
 	return clusterInfo, nil
 }
+
+// detectClusterMode detects whether the cluster has a hosted control plane and logs when spec.hostedCluster
+// overrides the detected mode.
+func detectClusterMode(logger logr.Logger, ci *v1alpha1.ClusterInfo, distribution string, infrastructure *configv1.Infrastructure, nodes []corev1.Node) string {
+	mode, reason := resources.DetectClusterMode(distribution, infrastructure, nodes)
+	logger.Info(fmt.Sprintf("Detected %s cluster: %s", mode, reason))
+	if ci.Spec.HostedCluster != nil && *ci.Spec.HostedCluster != (mode == v1alpha1.ClusterModeHosted) {
+		logger.Info(fmt.Sprintf("Detected mode %s is overridden by spec.hostedCluster=%t", mode, *ci.Spec.HostedCluster))
+	}
+	return mode
+}
+
+// collectIdentityProviders collects the identity providers of the cluster and reports the outcome in the
+// IdentityProvidersCollected condition. The previously collected identity providers are kept on failure.
+func collectIdentityProviders(ctx context.Context, logger logr.Logger, k8sClient client.Client, apiReader client.Reader, managementClients *resources.ManagementClientCache, ci *v1alpha1.ClusterInfo, distribution string, hosted bool, status *v1alpha1.ClusterInfoStatus) []v1alpha1.IdentityProvider {
+	idps, err := resources.GetIdentityProviders(ctx, logger, k8sClient, apiReader, managementClients, ci, distribution, hosted)
+	switch {
+	case errors.Is(err, resources.ErrManagementClusterNotConfigured):
+		setCondition(status, ci.Generation, v1alpha1.ConditionIdentityProvidersCollected, metav1.ConditionFalse, "ManagementClusterNotConfigured",
+			"spec.managementCluster is required to read the identity providers of a hosted cluster")
+		return nil
+	case err != nil:
+		logger.Error(err, "Failed to collect identity providers")
+		setCondition(status, ci.Generation, v1alpha1.ConditionIdentityProvidersCollected, metav1.ConditionFalse, "CollectionFailed", err.Error())
+		return ci.Status.IdentityProviders
+	}
+	setCondition(status, ci.Generation, v1alpha1.ConditionIdentityProvidersCollected, metav1.ConditionTrue, "Collected",
+		fmt.Sprintf("Found %d identity providers", len(idps)))
+	return idps
+}
+
+// collectNetBoxClient resolves the NetBox configuration and reports it in the NetBoxConfigured condition.
+// It returns nil when NetBox is not configured or its configuration is invalid.
+func collectNetBoxClient(ctx context.Context, logger logr.Logger, apiReader client.Reader, netBoxClients *resources.NetBoxClientCache, ci *v1alpha1.ClusterInfo, status *v1alpha1.ClusterInfoStatus) *netbox.Client {
+	nb, source, err := resources.GetNetBoxClient(ctx, logger, apiReader, netBoxClients, ci)
+	switch {
+	case errors.Is(err, netbox.ErrNotConfigured):
+		setCondition(status, ci.Generation, v1alpha1.ConditionNetBoxConfigured, metav1.ConditionFalse, "NotConfigured",
+			fmt.Sprintf("NetBox URL or token is not set in %s", source))
+		return nil
+	case err != nil:
+		logger.Error(err, "Invalid NetBox configuration")
+		setCondition(status, ci.Generation, v1alpha1.ConditionNetBoxConfigured, metav1.ConditionFalse, "InvalidConfiguration", err.Error())
+		return nil
+	}
+	setCondition(status, ci.Generation, v1alpha1.ConditionNetBoxConfigured, metav1.ConditionTrue, "Configured",
+		fmt.Sprintf("NetBox is configured from %s", source))
+	return nb
+}
+
+// collectSegmentDrift compares the cluster segments with NetBox when drift detection is enabled
+// and reports the result in the SegmentsInSync condition. The previous drift is kept when NetBox
+// cannot be queried.
+func collectSegmentDrift(ctx context.Context, logger logr.Logger, k8sClient client.Client, ci *v1alpha1.ClusterInfo, hosted bool, status *v1alpha1.ClusterInfoStatus, nb *netbox.Client, nodes []corev1.Node, segments []v1alpha1.Segment, clusterName string) *v1alpha1.SegmentDrift {
+	if ci.Spec.NetBox == nil || !ci.Spec.NetBox.DriftDetection {
+		meta.RemoveStatusCondition(&status.Conditions, v1alpha1.ConditionSegmentsInSync)
+		return nil
+	}
+
+	drift, err := resources.GetSegmentDrift(ctx, logger, k8sClient, ci, hosted, nb, nodes, segments, clusterName)
+	switch {
+	case errors.Is(err, netbox.ErrNotConfigured):
+		setCondition(status, ci.Generation, v1alpha1.ConditionSegmentsInSync, metav1.ConditionUnknown,
+			"NetBoxNotConfigured", "NetBox URL or token is not configured")
+		return nil
+	case err != nil:
+		logger.Error(err, "Failed to detect segment drift")
+		setCondition(status, ci.Generation, v1alpha1.ConditionSegmentsInSync, metav1.ConditionUnknown,
+			"DriftDetectionFailed", err.Error())
+		return ci.Status.SegmentDrift
+	case len(drift.Unregistered) > 0 || len(drift.Unused) > 0:
+		setCondition(status, ci.Generation, v1alpha1.ConditionSegmentsInSync, metav1.ConditionFalse, "DriftDetected",
+			fmt.Sprintf("%d segments are not registered in NetBox and %d registered prefixes are unused", len(drift.Unregistered), len(drift.Unused)))
+	default:
+		setCondition(status, ci.Generation, v1alpha1.ConditionSegmentsInSync, metav1.ConditionTrue, "InSync",
+			"All segments match the prefixes registered in NetBox")
+	}
+	return &drift
+}
+
+// collectNetBoxSync writes the collected cluster facts back to NetBox when enabled and reports
+// the result in the NetBoxSynced condition.
+func collectNetBoxSync(ctx context.Context, logger logr.Logger, ci *v1alpha1.ClusterInfo, status *v1alpha1.ClusterInfoStatus, nb *netbox.Client) *v1alpha1.NetBoxSync {
+	if ci.Spec.NetBox == nil || ci.Spec.NetBox.WriteBack == nil || !ci.Spec.NetBox.WriteBack.Enabled {
+		meta.RemoveStatusCondition(&status.Conditions, v1alpha1.ConditionNetBoxSynced)
+		return nil
+	}
+
+	sync, err := resources.SyncClusterToNetBox(ctx, logger, nb, *status, *ci.Spec.NetBox.WriteBack)
+	switch {
+	case errors.Is(err, netbox.ErrNotConfigured):
+		setCondition(status, ci.Generation, v1alpha1.ConditionNetBoxSynced, metav1.ConditionUnknown,
+			"NetBoxNotConfigured", "NetBox URL or token is not configured")
+		return nil
+	case err != nil:
+		logger.Error(err, "Failed to write cluster info to NetBox")
+		setCondition(status, ci.Generation, v1alpha1.ConditionNetBoxSynced, metav1.ConditionFalse, "SyncFailed", err.Error())
+		return nil
+	case sync.DryRun:
+		setCondition(status, ci.Generation, v1alpha1.ConditionNetBoxSynced, metav1.ConditionFalse, "DryRun",
+			fmt.Sprintf("%d changes are pending, dry-run is enabled", len(sync.Changes)))
+	default:
+		setCondition(status, ci.Generation, v1alpha1.ConditionNetBoxSynced, metav1.ConditionTrue, "Synced",
+			fmt.Sprintf("NetBox is up to date, %d changes applied", len(sync.Changes)))
+	}
+	return &sync
+}
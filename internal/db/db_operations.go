@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"time"
 
@@ -12,6 +13,15 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+const (
+	DatabaseName            = "axiom"
+	ClusterInfoCollection   = "clusterInfo"
+	OverlapReportCollection = "overlapReport"
+
+	// latestOverlapReportID is the ID of the document holding the latest overlap report.
+	latestOverlapReportID = "latest"
+)
+
 // InsertClusterInfoToMongo stores or updates cluster information in MongoDB database.
 // It connects to MongoDB using the MONGO_URI environment variable, and performs an upsert operation
 // based on the cluster ID. The function handles the connection lifecycle, including proper cleanup
@@ -19,29 +29,15 @@ import (
 func InsertClusterInfoToMongo(logger logr.Logger, clusterInfo v1alpha1.ClusterInfo) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
-	mongoURI, ok := os.LookupEnv("MONGO_URI")
-	if !ok {
-		logger.Error(nil, "MONGO_URI environment variable not set")
-		return
-	}
-	client, err := mongo.Connect(
-		ctx,
-		options.Client().ApplyURI(mongoURI),
-	)
+	client, err := connect(ctx)
 	if err != nil {
 		logger.Error(err, "Failed to connect to MongoDB")
 		return
 	}
-
-	defer func(client *mongo.Client, ctx context.Context) {
-		err := client.Disconnect(ctx)
-		if err != nil {
-			return
-		}
-	}(client, ctx)
+	defer disconnect(ctx, client)
 
 	if clusterInfo.Status.ClusterID != "" {
-		collection := client.Database("axiom").Collection("clusterInfo")
+		collection := client.Database(DatabaseName).Collection(ClusterInfoCollection)
 		filter := bson.M{"clusterID": clusterInfo.Status.ClusterID}
 		update := bson.M{"$set": clusterInfo.Status}
 		opts := options.Update().SetUpsert(true)
@@ -54,3 +50,118 @@ func InsertClusterInfoToMongo(logger logr.Logger, clusterInfo v1alpha1.ClusterIn
 	}
 	return
 }
+
+// legacyClusterInfo is the shape of the clusters stored before segments carried their NetBox metadata,
+// when the segments were plain prefixes.
+type legacyClusterInfo struct {
+	Name               string                   `bson:"name,omitempty"`
+	ClusterID          string                   `bson:"clusterID,omitempty"`
+	Segments           []string                 `bson:"segments,omitempty"`
+	ClusterNetwork     *v1alpha1.ClusterNetwork `bson:"clusterNetwork,omitempty"`
+	RouterLBAddresses  []string                 `bson:"routerLBAddress,omitempty"`
+	ApiServerAddresses []string                 `bson:"apiServerAddresses,omitempty"`
+}
+
+// ListClusterInfosFromMongo returns the cluster information of every cluster stored in MongoDB.
+// Only the identity, segments, networks and addresses of the clusters are loaded. Clusters stored with
+// plain prefix segments are converted, and clusters that cannot be decoded are logged and skipped.
+func ListClusterInfosFromMongo(ctx context.Context, logger logr.Logger) ([]v1alpha1.ClusterInfoStatus, error) {
+	client, err := connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer disconnect(ctx, client)
+
+	projection := bson.M{
+		"name":               1,
+		"clusterID":          1,
+		"segments":           1,
+		"clusterNetwork":     1,
+		"apiServerAddresses": 1,
+		"routerLBAddress":    1,
+	}
+	collection := client.Database(DatabaseName).Collection(ClusterInfoCollection)
+	cursor, err := collection.Find(ctx, bson.M{}, options.Find().SetProjection(projection))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cluster info from MongoDB: %w", err)
+	}
+
+	return decodeClusterInfos(ctx, logger, cursor)
+}
+
+// decodeClusterInfos decodes the clusters of a cursor one by one, skipping the ones that cannot be decoded
+// so that a single malformed document does not hide the other clusters. The cursor is closed once exhausted.
+func decodeClusterInfos(ctx context.Context, logger logr.Logger, cursor *mongo.Cursor) ([]v1alpha1.ClusterInfoStatus, error) {
+	defer func() {
+		_ = cursor.Close(ctx)
+	}()
+
+	var clusters []v1alpha1.ClusterInfoStatus
+	for cursor.Next(ctx) {
+		cluster, err := decodeClusterInfo(cursor.Current)
+		if err != nil {
+			logger.Error(err, fmt.Sprintf("Skipping cluster info %s that cannot be decoded", cursor.Current.Lookup("_id")))
+			continue
+		}
+		clusters = append(clusters, cluster)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list cluster info from MongoDB: %w", err)
+	}
+	return clusters, nil
+}
+
+// decodeClusterInfo decodes a cluster, converting the plain prefix segments of legacy clusters.
+func decodeClusterInfo(raw bson.Raw) (v1alpha1.ClusterInfoStatus, error) {
+	cluster := v1alpha1.ClusterInfoStatus{}
+	err := bson.Unmarshal(raw, &cluster)
+	if err == nil {
+		return cluster, nil
+	}
+
+	legacy := legacyClusterInfo{}
+	if bson.Unmarshal(raw, &legacy) != nil {
+		return cluster, err
+	}
+	cluster = v1alpha1.ClusterInfoStatus{
+		Name:               legacy.Name,
+		ClusterID:          legacy.ClusterID,
+		ClusterNetwork:     legacy.ClusterNetwork,
+		RouterLBAddresses:  legacy.RouterLBAddresses,
+		ApiServerAddresses: legacy.ApiServerAddresses,
+	}
+	for _, prefix := range legacy.Segments {
+		cluster.Segments = append(cluster.Segments, v1alpha1.Segment{Prefix: prefix})
+	}
+	return cluster, nil
+}
+
+// InsertOverlapReportToMongo replaces the latest overlap report stored in MongoDB with the given one.
+func InsertOverlapReportToMongo(ctx context.Context, report any) error {
+	client, err := connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer disconnect(ctx, client)
+
+	collection := client.Database(DatabaseName).Collection(OverlapReportCollection)
+	filter := bson.M{"_id": latestOverlapReportID}
+	opts := options.Replace().SetUpsert(true)
+	if _, err := collection.ReplaceOne(ctx, filter, report, opts); err != nil {
+		return fmt.Errorf("failed to insert overlap report to MongoDB: %w", err)
+	}
+	return nil
+}
+
+// connect connects to MongoDB using the MONGO_URI environment variable.
+func connect(ctx context.Context) (*mongo.Client, error) {
+	mongoURI, ok := os.LookupEnv("MONGO_URI")
+	if !ok {
+		return nil, fmt.Errorf("MONGO_URI environment variable not set")
+	}
+	return mongo.Connect(ctx, options.Client().ApplyURI(mongoURI))
+}
+
+func disconnect(ctx context.Context, client *mongo.Client) {
+	_ = client.Disconnect(ctx)
+}
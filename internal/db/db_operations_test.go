@@ -0,0 +1,31 @@
+package db
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/dana-team/axiom-operator/api/v1alpha1"
+)
+
+var _ = Describe("decodeClusterInfos", func() {
+	It("should convert legacy segments and skip undecodable clusters", func() {
+		cursor, err := mongo.NewCursorFromDocuments([]any{
+			bson.M{"_id": "a", "clusterID": "a", "name": "a", "segments": bson.A{bson.M{"prefix": "10.0.0.0/24", "vlanID": 10}}},
+			bson.M{"_id": "b", "clusterID": "b", "name": "b", "segments": bson.A{"10.0.1.0/24"}, "apiServerAddresses": bson.A{"10.0.1.1"}},
+			bson.M{"_id": "c", "clusterID": "c", "name": bson.A{"c"}},
+		}, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		clusters, err := decodeClusterInfos(context.Background(), log.Log, cursor)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clusters).To(Equal([]v1alpha1.ClusterInfoStatus{
+			{ClusterID: "a", Name: "a", Segments: []v1alpha1.Segment{{Prefix: "10.0.0.0/24", VLANID: 10}}},
+			{ClusterID: "b", Name: "b", Segments: []v1alpha1.Segment{{Prefix: "10.0.1.0/24"}}, ApiServerAddresses: []string{"10.0.1.1"}},
+		}))
+	})
+})
@@ -0,0 +1,14 @@
+package db
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestDB(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecs(t, "DB Suite")
+}
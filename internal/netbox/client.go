@@ -0,0 +1,249 @@
+package netbox
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	maxRetryBackoff  = 10 * time.Second
+	maxErrorBodySize = 1024
+)
+
+// Client is a minimal NetBox REST API client with TLS verification,
+// pagination, client side rate limiting and retries on 429/5xx responses.
+type Client struct {
+	baseURL      *url.URL
+	token        string
+	httpClient   *http.Client
+	limiter      *rate.Limiter
+	timeout      time.Duration
+	maxRetries   int
+	retryBackoff time.Duration
+	pageSize     int
+}
+
+// Page is a single page of a NetBox list response.
+type Page[T any] struct {
+	Count   int    `json:"count"`
+	Next    string `json:"next,omitempty"`
+	Results []T    `json:"results"`
+}
+
+// NewClient creates a Client from the given config.
+// It returns ErrNotConfigured when the URL or token is empty.
+func NewClient(cfg Config) (*Client, error) {
+	if cfg.URL == "" || cfg.Token == "" {
+		return nil, ErrNotConfigured
+	}
+	cfg = cfg.withDefaults()
+
+	baseURL, err := url.Parse(strings.TrimRight(cfg.URL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse NetBox URL: %w", err)
+	}
+	if baseURL.Scheme == "" || baseURL.Host == "" {
+		return nil, fmt.Errorf("NetBox URL %q must include a scheme and a host", cfg.URL)
+	}
+
+	tlsConfig, err := newTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	return &Client{
+		baseURL:      baseURL,
+		token:        cfg.Token,
+		httpClient:   &http.Client{Transport: transport},
+		limiter:      rate.NewLimiter(rate.Limit(cfg.RequestsPerSecond), cfg.Burst),
+		timeout:      cfg.Timeout,
+		maxRetries:   cfg.MaxRetries,
+		retryBackoff: cfg.RetryBackoff,
+		pageSize:     cfg.PageSize,
+	}, nil
+}
+
+// newTLSConfig builds the TLS configuration trusting the system pool and the configured CA bundle.
+func newTLSConfig(cfg Config) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.InsecureSkipVerify, //nolint:gosec // explicit opt-in
+	}
+	if len(cfg.CABundle) == 0 {
+		return tlsConfig, nil
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(cfg.CABundle) {
+		return nil, errors.New("failed to parse NetBox CA bundle: no valid PEM certificates found")
+	}
+	tlsConfig.RootCAs = pool
+	return tlsConfig, nil
+}
+
+// Get sends a GET request to the given API path and decodes the JSON response into out.
+func (c *Client) Get(ctx context.Context, path string, query url.Values, out any) error {
+	return c.Do(ctx, http.MethodGet, path, query, nil, out)
+}
+
+// Do sends a request to the given API path, e.g. /api/ipam/prefixes/, encoding body as JSON
+// when it is not nil and decoding the JSON response into out when it is not nil.
+// Requests are rate limited and retried with exponential backoff on 429 and 5xx responses.
+func (c *Client) Do(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("failed to encode NetBox request body: %w", err)
+		}
+	}
+
+	reqURL := c.resolve(path, query)
+	backoff := c.retryBackoff
+	for attempt := 0; ; attempt++ {
+		retryable, retryAfter, err := c.do(ctx, method, reqURL, payload, out)
+		if err == nil || !retryable || attempt >= c.maxRetries || ctx.Err() != nil {
+			return err
+		}
+
+		delay := backoff
+		if retryAfter > 0 {
+			delay = retryAfter
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+		backoff = min(backoff*2, maxRetryBackoff)
+	}
+}
+
+// do performs a single HTTP round trip. On failure it reports whether the attempt is worth
+// retrying and the server requested Retry-After delay, if any.
+func (c *Client) do(ctx context.Context, method, reqURL string, payload []byte, out any) (bool, time.Duration, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return false, 0, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, body)
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to create NetBox request: %w", err)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Token %s", c.token))
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		// Transport errors, including the per attempt timeout, are retried unless the
+		// request may already have created an object.
+		return method != http.MethodPost, 0, fmt.Errorf("failed to send NetBox request: %w", err)
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		errBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return isRetryableStatus(method, resp.StatusCode), parseRetryAfter(resp.Header.Get("Retry-After")), &APIError{
+			Method:     method,
+			URL:        reqURL,
+			StatusCode: resp.StatusCode,
+			Body:       strings.TrimSpace(string(errBody)),
+		}
+	}
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return false, 0, nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return false, 0, fmt.Errorf("failed to parse NetBox response: %w", err)
+	}
+	return false, 0, nil
+}
+
+// resolve joins the API path to the base URL, keeping any path prefix NetBox is served under.
+func (c *Client) resolve(path string, query url.Values) string {
+	u := *c.baseURL
+	u.Path = strings.TrimRight(u.Path, "/") + "/" + strings.TrimLeft(path, "/")
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// List fetches every object of a NetBox list endpoint, following the pagination links.
+func List[T any](ctx context.Context, c *Client, path string, query url.Values) ([]T, error) {
+	q := url.Values{}
+	for k, v := range query {
+		q[k] = append([]string(nil), v...)
+	}
+	if q.Get("limit") == "" || q.Get("limit") == "0" {
+		q.Set("limit", strconv.Itoa(c.pageSize))
+	}
+
+	var items []T
+	for {
+		var page Page[T]
+		if err := c.Get(ctx, path, q, &page); err != nil {
+			return nil, err
+		}
+		items = append(items, page.Results...)
+		if page.Next == "" || len(page.Results) == 0 {
+			return items, nil
+		}
+
+		// Only the query of the next link is used since NetBox behind a proxy often
+		// reports its internal scheme and host.
+		next, err := url.Parse(page.Next)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse NetBox next page link: %w", err)
+		}
+		q = next.Query()
+	}
+}
+
+// parseRetryAfter parses the seconds form of the Retry-After header.
+func parseRetryAfter(value string) time.Duration {
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return min(time.Duration(seconds)*time.Second, maxRetryBackoff)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
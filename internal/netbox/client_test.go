@@ -0,0 +1,133 @@
+package netbox_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/dana-team/axiom-operator/internal/netbox"
+	"github.com/dana-team/axiom-operator/internal/netbox/netboxtest"
+)
+
+const (
+	token        = "test-token"
+	prefixesPath = "/api/ipam/prefixes/"
+)
+
+type prefix struct {
+	ID     int    `json:"id"`
+	Prefix string `json:"prefix"`
+}
+
+var _ = Describe("NetBox Client", func() {
+	var (
+		server *netboxtest.Server
+		client *netbox.Client
+		ctx    context.Context
+	)
+
+	newClient := func(cfg netbox.Config) *netbox.Client {
+		c, err := netbox.NewClient(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		return c
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		server = netboxtest.NewServer(token)
+		client = newClient(netbox.Config{
+			URL:          server.URL,
+			Token:        token,
+			CABundle:     server.CABundle(),
+			RetryBackoff: time.Millisecond,
+			PageSize:     2,
+		})
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should return ErrNotConfigured without a URL or token", func() {
+		_, err := netbox.NewClient(netbox.Config{URL: server.URL})
+		Expect(err).To(MatchError(netbox.ErrNotConfigured))
+	})
+
+	It("should reject a server certificate that is not in the CA bundle", func() {
+		c := newClient(netbox.Config{URL: server.URL, Token: token, MaxRetries: -1})
+		err := c.Get(ctx, prefixesPath, nil, &netbox.Page[prefix]{})
+		Expect(err).To(MatchError(ContainSubstring("certificate")))
+	})
+
+	It("should follow pagination links and filter by custom field", func() {
+		for i := range 5 {
+			server.Add(prefixesPath, map[string]any{
+				"prefix":        fmt.Sprintf("10.0.%d.0/24", i),
+				"custom_fields": map[string]any{"Cluster": "a"},
+			})
+		}
+		server.Add(prefixesPath, map[string]any{
+			"prefix":        "10.1.0.0/24",
+			"custom_fields": map[string]any{"Cluster": "b"},
+		})
+
+		prefixes, err := netbox.List[prefix](ctx, client, prefixesPath, url.Values{"cf_Cluster": {"a"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(prefixes).To(HaveLen(5))
+		Expect(prefixes[4].Prefix).To(Equal("10.0.4.0/24"))
+		Expect(server.Requests()).To(HaveLen(3))
+	})
+
+	It("should retry rate limited and failed requests", func() {
+		server.Add(prefixesPath, map[string]any{"prefix": "10.0.0.0/24"})
+		server.FailNext(http.StatusTooManyRequests, http.StatusBadGateway)
+
+		prefixes, err := netbox.List[prefix](ctx, client, prefixesPath, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(prefixes).To(HaveLen(1))
+		Expect(server.Requests()).To(HaveLen(3))
+	})
+
+	It("should give up after the configured retries with a typed error", func() {
+		server.FailNext(http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusTooManyRequests)
+
+		_, err := netbox.List[prefix](ctx, client, prefixesPath, nil)
+		Expect(netbox.IsRateLimited(err)).To(BeTrue())
+		Expect(server.Requests()).To(HaveLen(netbox.DefaultMaxRetries + 1))
+	})
+
+	It("should not retry client errors", func() {
+		c := newClient(netbox.Config{URL: server.URL, Token: "wrong", CABundle: server.CABundle()})
+
+		_, err := netbox.List[prefix](ctx, c, prefixesPath, nil)
+		Expect(netbox.IsUnauthorized(err)).To(BeTrue())
+		Expect(server.Requests()).To(HaveLen(1))
+	})
+
+	It("should create, update and delete objects", func() {
+		created := prefix{}
+		Expect(client.Do(ctx, http.MethodPost, prefixesPath, nil, map[string]any{"prefix": "10.2.0.0/24"}, &created)).To(Succeed())
+		Expect(created.ID).NotTo(BeZero())
+
+		itemPath := fmt.Sprintf("%s%d/", prefixesPath, created.ID)
+		Expect(client.Do(ctx, http.MethodPatch, itemPath, nil, map[string]any{"prefix": "10.3.0.0/24"}, nil)).To(Succeed())
+		Expect(server.Objects(prefixesPath)[0]).To(HaveKeyWithValue("prefix", "10.3.0.0/24"))
+
+		Expect(client.Do(ctx, http.MethodDelete, itemPath, nil, nil, nil)).To(Succeed())
+		err := client.Get(ctx, itemPath, nil, &prefix{})
+		Expect(netbox.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should stop when the context is cancelled", func() {
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+
+		_, err := netbox.List[prefix](cancelled, client, prefixesPath, nil)
+		Expect(err).To(MatchError(context.Canceled))
+	})
+})
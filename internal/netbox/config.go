@@ -0,0 +1,99 @@
+package netbox
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	URLEnv                = "NETBOX_URL"
+	TokenEnv              = "NETBOX_TOKEN"
+	CAFileEnv             = "NETBOX_CA_FILE"
+	InsecureSkipVerifyEnv = "NETBOX_INSECURE_SKIP_VERIFY"
+
+	DefaultTimeout           = 30 * time.Second
+	DefaultRequestsPerSecond = 10
+	DefaultBurst             = 5
+	DefaultMaxRetries        = 3
+	DefaultPageSize          = 100
+	DefaultRetryBackoff      = 500 * time.Millisecond
+)
+
+// Config holds the settings used to build a NetBox Client.
+// Zero values are replaced with the package defaults by NewClient.
+type Config struct {
+	// URL is the base address of NetBox, e.g. https://netbox.example.com.
+	URL string
+	// Token is the NetBox API token sent in the Authorization header.
+	Token string
+	// CABundle holds PEM encoded certificates trusted in addition to the system pool.
+	CABundle []byte
+	// InsecureSkipVerify disables TLS certificate verification. It should only be used for testing.
+	InsecureSkipVerify bool
+	// Timeout bounds every single HTTP request, including each retry attempt.
+	Timeout time.Duration
+	// RequestsPerSecond and Burst configure the client side rate limiter.
+	RequestsPerSecond float64
+	Burst             int
+	// MaxRetries is the number of times a request is retried on 429 and 5xx responses.
+	// A negative value disables retries.
+	MaxRetries int
+	// RetryBackoff is the initial delay between retries, doubled on every attempt.
+	RetryBackoff time.Duration
+	// PageSize is the number of objects requested per page when listing.
+	PageSize int
+}
+
+// ConfigFromEnv builds a Config from the NETBOX_* environment variables.
+// The CA bundle is read from the file referenced by NETBOX_CA_FILE when set.
+func ConfigFromEnv() (Config, error) {
+	cfg := Config{
+		URL:   os.Getenv(URLEnv),
+		Token: os.Getenv(TokenEnv),
+	}
+
+	if caFile := os.Getenv(CAFileEnv); caFile != "" {
+		caBundle, err := os.ReadFile(caFile)
+		if err != nil {
+			return Config{}, fmt.Errorf("failed to read NetBox CA bundle %s: %w", caFile, err)
+		}
+		cfg.CABundle = caBundle
+	}
+
+	if insecure := os.Getenv(InsecureSkipVerifyEnv); insecure != "" {
+		skip, err := strconv.ParseBool(insecure)
+		if err != nil {
+			return Config{}, fmt.Errorf("failed to parse %s: %w", InsecureSkipVerifyEnv, err)
+		}
+		cfg.InsecureSkipVerify = skip
+	}
+
+	return cfg, nil
+}
+
+// withDefaults returns a copy of the config with unset tuning fields replaced by defaults.
+func (c Config) withDefaults() Config {
+	if c.Timeout <= 0 {
+		c.Timeout = DefaultTimeout
+	}
+	if c.RequestsPerSecond <= 0 {
+		c.RequestsPerSecond = DefaultRequestsPerSecond
+	}
+	if c.Burst <= 0 {
+		c.Burst = DefaultBurst
+	}
+	if c.MaxRetries < 0 {
+		c.MaxRetries = 0
+	} else if c.MaxRetries == 0 {
+		c.MaxRetries = DefaultMaxRetries
+	}
+	if c.RetryBackoff <= 0 {
+		c.RetryBackoff = DefaultRetryBackoff
+	}
+	if c.PageSize <= 0 {
+		c.PageSize = DefaultPageSize
+	}
+	return c
+}
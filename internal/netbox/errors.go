@@ -0,0 +1,54 @@
+package netbox
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrNotConfigured is returned by NewClient when the NetBox URL or token is missing.
+var ErrNotConfigured = errors.New("netbox URL or token is not configured")
+
+// APIError is returned when NetBox answers a request with a non-2xx status code.
+type APIError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("netbox %s %s failed with status code %d", e.Method, e.URL, e.StatusCode)
+	}
+	return fmt.Sprintf("netbox %s %s failed with status code %d: %s", e.Method, e.URL, e.StatusCode, e.Body)
+}
+
+// IsNotFound returns true if the error is a NetBox 404 response.
+func IsNotFound(err error) bool {
+	return hasStatusCode(err, http.StatusNotFound)
+}
+
+// IsUnauthorized returns true if NetBox rejected the request credentials.
+func IsUnauthorized(err error) bool {
+	return hasStatusCode(err, http.StatusUnauthorized) || hasStatusCode(err, http.StatusForbidden)
+}
+
+// IsRateLimited returns true if NetBox kept answering with 429 after all retries were used.
+func IsRateLimited(err error) bool {
+	return hasStatusCode(err, http.StatusTooManyRequests)
+}
+
+func hasStatusCode(err error, statusCode int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == statusCode
+}
+
+// isRetryableStatus reports whether a response status code is worth retrying.
+// Server errors on POST are not retried since the object may have been created.
+func isRetryableStatus(method string, statusCode int) bool {
+	if statusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return statusCode >= http.StatusInternalServerError && method != http.MethodPost
+}
@@ -0,0 +1,282 @@
+// Package netboxtest provides an in-memory fake of the NetBox REST API for unit tests.
+package netboxtest
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const defaultLimit = 50
+
+// relationFields are the fields written as an ID and returned by NetBox as a nested object.
+var relationFields = map[string]struct{}{
+	"cluster":         {},
+	"device":          {},
+	"virtual_machine": {},
+	"primary_ip4":     {},
+	"primary_ip6":     {},
+	"site":            {},
+	"tenant":          {},
+	"vlan":            {},
+	"vrf":             {},
+	"role":            {},
+}
+
+// Server is a fake NetBox served over TLS by httptest. Objects are stored per list endpoint,
+// e.g. /api/ipam/prefixes/, and support listing with field filters and pagination,
+// creation, partial updates and deletion.
+type Server struct {
+	*httptest.Server
+
+	token    string
+	mu       sync.Mutex
+	nextID   int
+	objects  map[string]map[int]map[string]any
+	failures []int
+	requests []string
+}
+
+// NewServer starts a fake NetBox accepting the given API token.
+func NewServer(token string) *Server {
+	s := &Server{
+		token:   token,
+		objects: map[string]map[int]map[string]any{},
+	}
+	s.Server = httptest.NewTLSServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// CABundle returns the PEM encoded certificate of the fake server.
+func (s *Server) CABundle() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.Certificate().Raw})
+}
+
+// Add stores an object under the given list endpoint and returns its ID.
+func (s *Server) Add(path string, obj map[string]any) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.add(path, obj)
+}
+
+// Objects returns the objects stored under the given list endpoint ordered by ID.
+func (s *Server) Objects(path string) []map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.list(path)
+}
+
+// FailNext makes the next requests fail with the given status codes, in order.
+func (s *Server) FailNext(statusCodes ...int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, statusCodes...)
+}
+
+// Requests returns the method and request URI of every request received so far.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, r.Method+" "+r.URL.RequestURI())
+
+	if r.Header.Get("Authorization") != "Token "+s.token {
+		writeJSON(w, http.StatusForbidden, map[string]any{"detail": "Invalid token"})
+		return
+	}
+	if len(s.failures) > 0 {
+		statusCode := s.failures[0]
+		s.failures = s.failures[1:]
+		if statusCode == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "0")
+		}
+		writeJSON(w, statusCode, map[string]any{"detail": http.StatusText(statusCode)})
+		return
+	}
+
+	path, id, isItem := splitItemPath(r.URL.Path)
+	switch {
+	case !isItem && r.Method == http.MethodGet:
+		s.handleList(w, r, path)
+	case !isItem && r.Method == http.MethodPost:
+		var obj map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&obj); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]any{"detail": err.Error()})
+			return
+		}
+		s.add(path, nestRelations(obj))
+		writeJSON(w, http.StatusCreated, obj)
+	case isItem && s.objects[path][id] == nil:
+		writeJSON(w, http.StatusNotFound, map[string]any{"detail": "Not found."})
+	case isItem && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, s.objects[path][id])
+	case isItem && r.Method == http.MethodPatch:
+		var patch map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]any{"detail": err.Error()})
+			return
+		}
+		obj := s.objects[path][id]
+		for k, v := range nestRelations(patch) {
+			obj[k] = v
+		}
+		writeJSON(w, http.StatusOK, obj)
+	case isItem && r.Method == http.MethodDelete:
+		delete(s.objects[path], id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]any{"detail": "Method not allowed."})
+	}
+}
+
+func (s *Server) handleList(w http.ResponseWriter, r *http.Request, path string) {
+	query := r.URL.Query()
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	offset, _ := strconv.Atoi(query.Get("offset"))
+
+	var matched []map[string]any
+	for _, obj := range s.list(path) {
+		if matches(obj, query) {
+			matched = append(matched, obj)
+		}
+	}
+
+	end := min(offset+limit, len(matched))
+	results := []map[string]any{}
+	if offset < end {
+		results = matched[offset:end]
+	}
+
+	var next any
+	if end < len(matched) {
+		nextQuery := url.Values{}
+		for k, v := range query {
+			nextQuery[k] = v
+		}
+		nextQuery.Set("limit", strconv.Itoa(limit))
+		nextQuery.Set("offset", strconv.Itoa(end))
+		next = s.URL + path + "?" + nextQuery.Encode()
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"count":    len(matched),
+		"next":     next,
+		"previous": nil,
+		"results":  results,
+	})
+}
+
+func (s *Server) add(path string, obj map[string]any) int {
+	s.nextID++
+	obj["id"] = s.nextID
+	if s.objects[path] == nil {
+		s.objects[path] = map[int]map[string]any{}
+	}
+	s.objects[path][s.nextID] = obj
+	return s.nextID
+}
+
+func (s *Server) list(path string) []map[string]any {
+	ids := make([]int, 0, len(s.objects[path]))
+	for id := range s.objects[path] {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	out := make([]map[string]any, 0, len(ids))
+	for _, id := range ids {
+		out = append(out, s.objects[path][id])
+	}
+	return out
+}
+
+// nestRelations replaces the IDs of related objects with nested objects, as returned by NetBox.
+func nestRelations(obj map[string]any) map[string]any {
+	for k, v := range obj {
+		if _, ok := relationFields[k]; !ok {
+			continue
+		}
+		if id, ok := v.(float64); ok {
+			obj[k] = map[string]any{"id": int(id)}
+		}
+	}
+	return obj
+}
+
+// splitItemPath splits /api/ipam/prefixes/3/ into the list endpoint and the object ID.
+func splitItemPath(path string) (string, int, bool) {
+	trimmed := strings.TrimSuffix(path, "/")
+	idx := strings.LastIndex(trimmed, "/")
+	id, err := strconv.Atoi(trimmed[idx+1:])
+	if err != nil {
+		return path, 0, false
+	}
+	return trimmed[:idx+1], id, true
+}
+
+// matches applies NetBox style filters: plain fields, cf_<name> custom fields, <field>_id
+// for nested objects and address for IP addresses. Nested objects also match on their name or slug.
+func matches(obj map[string]any, query url.Values) bool {
+	for key, values := range query {
+		if key == "limit" || key == "offset" || key == "brief" {
+			continue
+		}
+
+		var field any
+		switch {
+		case strings.HasPrefix(key, "cf_"):
+			customFields, _ := obj["custom_fields"].(map[string]any)
+			field = customFields[strings.TrimPrefix(key, "cf_")]
+		case strings.HasSuffix(key, "_id") && obj[key] == nil:
+			nested, _ := obj[strings.TrimSuffix(key, "_id")].(map[string]any)
+			field = nested["id"]
+		case key == "address":
+			// IP addresses are filtered by host, ignoring the prefix length.
+			address, _ := obj[key].(string)
+			field = strings.SplitN(address, "/", 2)[0]
+		default:
+			field = obj[key]
+		}
+
+		if !matchesAny(field, values) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesAny(field any, values []string) bool {
+	candidates := []string{fmt.Sprint(field)}
+	if nested, ok := field.(map[string]any); ok {
+		candidates = []string{fmt.Sprint(nested["id"]), fmt.Sprint(nested["name"]), fmt.Sprint(nested["slug"])}
+	}
+	for _, value := range values {
+		for _, candidate := range candidates {
+			if field != nil && candidate == value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,14 @@
+package netbox_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestNetBox(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecs(t, "NetBox Suite")
+}
@@ -0,0 +1,63 @@
+package overlap
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dana-team/axiom-operator/api/v1alpha1"
+	"github.com/go-logr/logr"
+)
+
+// Detector periodically loads the clusters of the central inventory, detects the conflicts between them and
+// stores the report. It implements manager.Runnable and only runs on the elected leader.
+type Detector struct {
+	Logger   logr.Logger
+	Interval time.Duration
+	// Load returns the clusters of the inventory.
+	Load func(ctx context.Context) ([]v1alpha1.ClusterInfoStatus, error)
+	// Store persists the report.
+	Store func(ctx context.Context, report Report) error
+}
+
+// Start runs a detection immediately and then on every interval until the context is cancelled.
+func (d *Detector) Start(ctx context.Context) error {
+	ticker := time.NewTicker(d.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := d.RunOnce(ctx); err != nil {
+			d.Logger.Error(err, "Failed to detect cluster overlaps")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection makes the detector run on the elected leader only.
+func (d *Detector) NeedLeaderElection() bool {
+	return true
+}
+
+// RunOnce detects the conflicts between the clusters of the inventory and stores the report.
+// A run is cancelled if it takes longer than the interval.
+func (d *Detector) RunOnce(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, d.Interval)
+	defer cancel()
+
+	clusters, err := d.Load(ctx)
+	if err != nil {
+		return err
+	}
+
+	report := Detect(clusters, time.Now().UTC())
+	for _, conflict := range report.Conflicts {
+		d.Logger.Info(fmt.Sprintf("Found conflict: %s", conflict))
+	}
+	d.Logger.Info(fmt.Sprintf("Found %d conflicts between %d clusters", len(report.Conflicts), report.Clusters))
+
+	return d.Store(ctx, report)
+}
@@ -0,0 +1,178 @@
+// Package overlap detects IP collisions between the clusters of the central inventory.
+package overlap
+
+import (
+	"fmt"
+	"net/netip"
+	"sort"
+	"time"
+
+	"github.com/dana-team/axiom-operator/api/v1alpha1"
+)
+
+const (
+	// ConflictNetworkOverlap is reported for two networks of different clusters sharing addresses.
+	ConflictNetworkOverlap = "NetworkOverlap"
+	// ConflictDuplicateAddress is reported for an address used by several clusters.
+	ConflictDuplicateAddress = "DuplicateAddress"
+
+	ResourceSegment          = "Segment"
+	ResourcePodNetwork       = "PodNetwork"
+	ResourceServiceNetwork   = "ServiceNetwork"
+	ResourceMachineNetwork   = "MachineNetwork"
+	ResourceAPIServerAddress = "APIServerAddress"
+	ResourceRouterLBAddress  = "RouterLBAddress"
+)
+
+// Resource is a network or address of a cluster involved in a conflict.
+type Resource struct {
+	ClusterID   string `json:"clusterID" bson:"clusterID"`
+	ClusterName string `json:"clusterName,omitempty" bson:"clusterName,omitempty"`
+	Type        string `json:"type" bson:"type"`
+	Value       string `json:"value" bson:"value"`
+}
+
+// Conflict is a collision between resources of different clusters.
+type Conflict struct {
+	Type      string     `json:"type" bson:"type"`
+	Resources []Resource `json:"resources" bson:"resources"`
+}
+
+// Report lists the conflicts found between the clusters of the inventory.
+type Report struct {
+	GeneratedAt time.Time  `json:"generatedAt" bson:"generatedAt"`
+	Clusters    int        `json:"clusters" bson:"clusters"`
+	Conflicts   []Conflict `json:"conflicts" bson:"conflicts"`
+}
+
+type network struct {
+	resource Resource
+	prefix   netip.Prefix
+}
+
+// Detect reports the overlapping segments, pod, service and machine networks and the duplicate API server and
+// router load balancer addresses between the given clusters. Networks of the same cluster are never compared, and
+// values that cannot be parsed as CIDRs are ignored.
+func Detect(clusters []v1alpha1.ClusterInfoStatus, now time.Time) Report {
+	report := Report{GeneratedAt: now, Clusters: len(clusters), Conflicts: []Conflict{}}
+
+	var networks []network
+	addresses := map[string][]Resource{}
+	for _, cluster := range clusters {
+		networks = append(networks, clusterNetworks(cluster)...)
+		for _, resource := range clusterAddresses(cluster) {
+			addresses[resource.Value] = append(addresses[resource.Value], resource)
+		}
+	}
+
+	// Sorting by the first address of the networks bounds the comparisons to the networks starting
+	// before the end of the current one.
+	sort.Slice(networks, func(i, j int) bool {
+		if networks[i].prefix.Addr() != networks[j].prefix.Addr() {
+			return networks[i].prefix.Addr().Less(networks[j].prefix.Addr())
+		}
+		if networks[i].prefix.Bits() != networks[j].prefix.Bits() {
+			return networks[i].prefix.Bits() < networks[j].prefix.Bits()
+		}
+		return networks[i].resource.ClusterID < networks[j].resource.ClusterID
+	})
+	for i := range networks {
+		for j := i + 1; j < len(networks) && networks[i].prefix.Contains(networks[j].prefix.Addr()); j++ {
+			if networks[i].resource.ClusterID == networks[j].resource.ClusterID {
+				continue
+			}
+			report.Conflicts = append(report.Conflicts, Conflict{
+				Type:      ConflictNetworkOverlap,
+				Resources: []Resource{networks[i].resource, networks[j].resource},
+			})
+		}
+	}
+
+	values := make([]string, 0, len(addresses))
+	for value := range addresses {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	for _, value := range values {
+		if resources := addresses[value]; countClusters(resources) > 1 {
+			report.Conflicts = append(report.Conflicts, Conflict{Type: ConflictDuplicateAddress, Resources: resources})
+		}
+	}
+
+	return report
+}
+
+// clusterNetworks returns the segments and the pod, service and machine networks of a cluster.
+// Identical networks of different types, such as a segment also listed as a machine network, are only returned once.
+func clusterNetworks(cluster v1alpha1.ClusterInfoStatus) []network {
+	var networks []network
+	seen := map[netip.Prefix]struct{}{}
+	add := func(resourceType, value string) {
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			return
+		}
+		prefix = prefix.Masked()
+		if _, ok := seen[prefix]; ok {
+			return
+		}
+		seen[prefix] = struct{}{}
+		networks = append(networks, network{resource: newResource(cluster, resourceType, value), prefix: prefix})
+	}
+
+	for _, segment := range cluster.Segments {
+		add(ResourceSegment, segment.Prefix)
+	}
+	if cluster.ClusterNetwork != nil {
+		for _, entry := range cluster.ClusterNetwork.ClusterNetworks {
+			add(ResourcePodNetwork, entry.CIDR)
+		}
+		for _, cidr := range cluster.ClusterNetwork.ServiceNetworks {
+			add(ResourceServiceNetwork, cidr)
+		}
+		for _, cidr := range cluster.ClusterNetwork.MachineNetworks {
+			add(ResourceMachineNetwork, cidr)
+		}
+	}
+	return networks
+}
+
+// clusterAddresses returns the unique API server and router load balancer addresses of a cluster.
+func clusterAddresses(cluster v1alpha1.ClusterInfoStatus) []Resource {
+	var resources []Resource
+	seen := map[string]struct{}{}
+	add := func(resourceType string, values []string) {
+		for _, value := range values {
+			if _, ok := seen[value]; ok || value == "" {
+				continue
+			}
+			seen[value] = struct{}{}
+			resources = append(resources, newResource(cluster, resourceType, value))
+		}
+	}
+
+	add(ResourceAPIServerAddress, cluster.ApiServerAddresses)
+	add(ResourceRouterLBAddress, cluster.RouterLBAddresses)
+	return resources
+}
+
+func newResource(cluster v1alpha1.ClusterInfoStatus, resourceType, value string) Resource {
+	return Resource{ClusterID: cluster.ClusterID, ClusterName: cluster.Name, Type: resourceType, Value: value}
+}
+
+func countClusters(resources []Resource) int {
+	clusters := map[string]struct{}{}
+	for _, resource := range resources {
+		clusters[resource.ClusterID] = struct{}{}
+	}
+	return len(clusters)
+}
+
+// String summarizes the conflict for logging.
+func (c Conflict) String() string {
+	out := c.Type
+	for _, resource := range c.Resources {
+		out += fmt.Sprintf(" %s/%s=%s", resource.ClusterName, resource.Type, resource.Value)
+	}
+	return out
+}
@@ -0,0 +1,61 @@
+package overlap_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/dana-team/axiom-operator/api/v1alpha1"
+	"github.com/dana-team/axiom-operator/internal/overlap"
+)
+
+var _ = Describe("Detect", func() {
+	It("should report overlapping networks and duplicate addresses between clusters", func() {
+		clusters := []v1alpha1.ClusterInfoStatus{
+			{
+				ClusterID:          "a",
+				Name:               "cluster-a",
+				Segments:           []v1alpha1.Segment{{Prefix: "10.0.0.0/24"}, {Prefix: "10.0.1.0/24"}},
+				ClusterNetwork:     &v1alpha1.ClusterNetwork{ClusterNetworks: []v1alpha1.ClusterNetworkEntry{{CIDR: "10.128.0.0/14"}}},
+				ApiServerAddresses: []string{"10.0.0.5"},
+			},
+			{
+				ClusterID:         "b",
+				Name:              "cluster-b",
+				Segments:          []v1alpha1.Segment{{Prefix: "10.0.0.0/16"}},
+				ClusterNetwork:    &v1alpha1.ClusterNetwork{ServiceNetworks: []string{"10.130.0.0/16", "fd02::/112"}},
+				RouterLBAddresses: []string{"10.0.0.5"},
+			},
+		}
+
+		report := overlap.Detect(clusters, time.Time{})
+		Expect(report.Clusters).To(Equal(2))
+		Expect(report.Conflicts).To(ConsistOf(
+			overlap.Conflict{Type: overlap.ConflictNetworkOverlap, Resources: []overlap.Resource{
+				{ClusterID: "b", ClusterName: "cluster-b", Type: overlap.ResourceSegment, Value: "10.0.0.0/16"},
+				{ClusterID: "a", ClusterName: "cluster-a", Type: overlap.ResourceSegment, Value: "10.0.0.0/24"},
+			}},
+			overlap.Conflict{Type: overlap.ConflictNetworkOverlap, Resources: []overlap.Resource{
+				{ClusterID: "b", ClusterName: "cluster-b", Type: overlap.ResourceSegment, Value: "10.0.0.0/16"},
+				{ClusterID: "a", ClusterName: "cluster-a", Type: overlap.ResourceSegment, Value: "10.0.1.0/24"},
+			}},
+			overlap.Conflict{Type: overlap.ConflictNetworkOverlap, Resources: []overlap.Resource{
+				{ClusterID: "a", ClusterName: "cluster-a", Type: overlap.ResourcePodNetwork, Value: "10.128.0.0/14"},
+				{ClusterID: "b", ClusterName: "cluster-b", Type: overlap.ResourceServiceNetwork, Value: "10.130.0.0/16"},
+			}},
+			overlap.Conflict{Type: overlap.ConflictDuplicateAddress, Resources: []overlap.Resource{
+				{ClusterID: "a", ClusterName: "cluster-a", Type: overlap.ResourceAPIServerAddress, Value: "10.0.0.5"},
+				{ClusterID: "b", ClusterName: "cluster-b", Type: overlap.ResourceRouterLBAddress, Value: "10.0.0.5"},
+			}},
+		))
+	})
+
+	It("should not compare the networks of the same cluster", func() {
+		clusters := []v1alpha1.ClusterInfoStatus{{
+			ClusterID: "a",
+			Segments:  []v1alpha1.Segment{{Prefix: "10.0.0.0/16"}, {Prefix: "10.0.1.0/24"}},
+		}}
+		Expect(overlap.Detect(clusters, time.Time{}).Conflicts).To(BeEmpty())
+	})
+})
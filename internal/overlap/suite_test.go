@@ -0,0 +1,13 @@
+package overlap_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestOverlap(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Overlap Suite")
+}